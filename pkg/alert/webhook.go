@@ -0,0 +1,213 @@
+// Package alert notifies external services when checks go unhealthy.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danpilch/umd/pkg/output"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// DefaultTimeout bounds each webhook POST when Client is nil.
+const DefaultTimeout = 10 * time.Second
+
+// Payload is the JSON body POSTed to the webhook. Text is a one-line summary,
+// the only field a Slack incoming webhook reads; other receivers can use the
+// structured fields.
+type Payload struct {
+	Text        string                  `json:"text"`
+	Status      string                  `json:"status"` // "firing" or "resolved"
+	Timestamp   time.Time               `json:"timestamp"`
+	Checks      []use.Check             `json:"checks,omitempty"`
+	Resolved    []use.Check             `json:"resolved,omitempty"`
+	Suggestions map[string][]Suggestion `json:"suggestions,omitempty"`
+}
+
+// Suggestion is a drill-down next step for an alerting check.
+type Suggestion struct {
+	Tool    string `json:"tool"`
+	Command string `json:"command"`
+	Reason  string `json:"reason"`
+}
+
+// firing records when a check last alerted and at which status.
+type firing struct {
+	status use.Status
+	sent   time.Time
+}
+
+// WebhookAlerter POSTs a JSON payload to URL when checks go WARNING or ERROR.
+// It tracks which checks are already firing so a persistent condition alerts
+// once rather than on every watch tick: a check alerts when it enters
+// WARNING or ERROR, when it escalates from WARNING to ERROR, and again every
+// Repeat while it stays unhealthy. Once a firing check returns to OK a
+// "resolved" payload is sent. Safe for concurrent use.
+type WebhookAlerter struct {
+	URL string
+
+	// Client sends the POSTs; nil uses a client with DefaultTimeout.
+	Client *http.Client
+
+	// Repeat re-alerts checks still firing after this long. Zero alerts only
+	// on transitions.
+	Repeat time.Duration
+
+	mu     sync.Mutex
+	active map[string]firing
+}
+
+// NewWebhookAlerter creates an alerter that POSTs to url.
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{URL: url}
+}
+
+// Notify compares checks with the conditions already alerted on and POSTs
+// any new alerts and resolutions. Nothing is sent while nothing changed.
+// State is only updated once the POST succeeds, so a failed delivery is
+// retried on the next call. Checks are tracked by use.Check.Key; a firing
+// check missing from checks, e.g. because its collector was skipped this
+// tick, stays firing until it is reported again.
+func (a *WebhookAlerter) Notify(ctx context.Context, checks []use.Check) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	next := make(map[string]firing)
+	seen := make(map[string]bool)
+	var alerting, resolved []use.Check
+	for _, c := range worstByKey(checks) {
+		key := c.Key()
+		seen[key] = true
+		prev, wasFiring := a.active[key]
+		switch c.Status {
+		case use.StatusWarning, use.StatusError:
+			escalated := wasFiring && prev.status == use.StatusWarning && c.Status == use.StatusError
+			due := wasFiring && a.Repeat > 0 && now.Sub(prev.sent) >= a.Repeat
+			if !wasFiring || escalated || due {
+				alerting = append(alerting, c)
+				next[key] = firing{status: c.Status, sent: now}
+			} else {
+				next[key] = firing{status: c.Status, sent: prev.sent}
+			}
+		case use.StatusUnknown:
+			// No reading this tick; neither alert nor resolve
+			if wasFiring {
+				next[key] = prev
+			}
+		default:
+			if wasFiring {
+				resolved = append(resolved, c)
+			}
+		}
+	}
+	for key, f := range a.active {
+		if !seen[key] {
+			next[key] = f
+		}
+	}
+
+	if len(alerting) == 0 && len(resolved) == 0 {
+		a.active = next
+		return nil
+	}
+	if err := a.post(ctx, newPayload(alerting, resolved, now)); err != nil {
+		return err
+	}
+	a.active = next
+	return nil
+}
+
+// worstByKey returns one check per key, the one with the worst status, in
+// first-seen order, so two checks sharing a key can't alternately fire and
+// resolve it within one sample.
+func worstByKey(checks []use.Check) []use.Check {
+	index := make(map[string]int, len(checks))
+	var out []use.Check
+	for _, c := range checks {
+		i, ok := index[c.Key()]
+		if !ok {
+			index[c.Key()] = len(out)
+			out = append(out, c)
+			continue
+		}
+		if c.Status.Worse(out[i].Status) {
+			out[i] = c
+		}
+	}
+	return out
+}
+
+// Watch returns an emit function for watch.Run that alerts on each sample
+// before passing it to next, which may be nil. Delivery errors go to onErr
+// when it is non-nil; they never stop the watch loop.
+func (a *WebhookAlerter) Watch(next func([]use.Check), onErr func(error)) func([]use.Check) {
+	return func(checks []use.Check) {
+		if err := a.Notify(context.Background(), checks); err != nil && onErr != nil {
+			onErr(err)
+		}
+		if next != nil {
+			next(checks)
+		}
+	}
+}
+
+// newPayload builds the webhook body for new alerts and resolutions.
+func newPayload(alerting, resolved []use.Check, now time.Time) Payload {
+	p := Payload{Status: "firing", Timestamp: now, Checks: alerting, Resolved: resolved}
+	if len(alerting) == 0 {
+		p.Status = "resolved"
+	}
+
+	var parts []string
+	for _, c := range alerting {
+		parts = append(parts, fmt.Sprintf("%s %s %s (%s)", strings.ToUpper(string(c.Status)), c.Resource, c.Type, c.Value))
+	}
+	for _, c := range resolved {
+		parts = append(parts, fmt.Sprintf("RESOLVED %s %s (%s)", c.Resource, c.Type, c.Value))
+	}
+	p.Text = "umd: " + strings.Join(parts, "; ")
+
+	if drill := output.GetDrillDownSuggestions(alerting); len(drill) > 0 {
+		p.Suggestions = make(map[string][]Suggestion, len(drill))
+		for resource, ss := range drill {
+			for _, s := range ss {
+				p.Suggestions[resource] = append(p.Suggestions[resource], Suggestion(s))
+			}
+		}
+	}
+	return p
+}
+
+// post sends the payload, treating any non-2xx response as a failure.
+func (a *WebhookAlerter) post(ctx context.Context, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("alert webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := a.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("alert webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alert webhook: %s returned %s", a.URL, resp.Status)
+	}
+	return nil
+}
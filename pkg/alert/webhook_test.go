@@ -0,0 +1,113 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// recorder is a webhook receiver that keeps every payload it is sent.
+type recorder struct {
+	mu       sync.Mutex
+	payloads []Payload
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var p Payload
+	if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.payloads = append(r.payloads, p)
+	r.mu.Unlock()
+}
+
+func (r *recorder) statuses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, p := range r.payloads {
+		out = append(out, p.Status)
+	}
+	return out
+}
+
+func newTestAlerter(t *testing.T) (*WebhookAlerter, *recorder) {
+	t.Helper()
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+	return NewWebhookAlerter(srv.URL), rec
+}
+
+func TestNotifySameResourceAndType(t *testing.T) {
+	a, rec := newTestAlerter(t)
+
+	// Disk capacity and inode usage share Resource and Type
+	checks := []use.Check{
+		{Resource: "Filesystem (/)", Type: use.Utilization, Value: "85.0%", Status: use.StatusWarning},
+		{Resource: "Filesystem (/)", Type: use.Utilization, Value: "3.0% inodes", Status: use.StatusOK, Kind: "inodes"},
+	}
+	for i := 0; i < 3; i++ {
+		if err := a.Notify(context.Background(), checks); err != nil {
+			t.Fatalf("tick %d: %v", i, err)
+		}
+	}
+
+	got := rec.statuses()
+	if len(got) != 1 || got[0] != "firing" {
+		t.Fatalf("payloads = %v, want a single firing alert", got)
+	}
+}
+
+func TestNotifyDuplicateKeyUsesWorstStatus(t *testing.T) {
+	a, rec := newTestAlerter(t)
+
+	checks := []use.Check{
+		{Resource: "Disk (sda)", Type: use.Saturation, Status: use.StatusOK},
+		{Resource: "Disk (sda)", Type: use.Saturation, Status: use.StatusError},
+	}
+	for i := 0; i < 2; i++ {
+		if err := a.Notify(context.Background(), checks); err != nil {
+			t.Fatalf("tick %d: %v", i, err)
+		}
+	}
+
+	got := rec.statuses()
+	if len(got) != 1 || got[0] != "firing" {
+		t.Fatalf("payloads = %v, want a single firing alert", got)
+	}
+}
+
+func TestNotifyKeepsMissingChecksFiring(t *testing.T) {
+	a, rec := newTestAlerter(t)
+	ctx := context.Background()
+
+	warn := use.Check{Resource: "CPU", Type: use.Saturation, Status: use.StatusWarning}
+	ok := warn
+	ok.Status = use.StatusOK
+
+	steps := [][]use.Check{
+		{warn}, // fires
+		{},     // CPU not reported: neither forgotten nor resolved
+		{warn}, // still firing, no repeat alert
+		{ok},   // resolves
+	}
+	for i, checks := range steps {
+		if err := a.Notify(ctx, checks); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	got := rec.statuses()
+	want := []string{"firing", "resolved"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("payloads = %v, want %v", got, want)
+	}
+}
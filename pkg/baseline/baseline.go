@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/danpilch/umd/pkg/use"
@@ -68,6 +69,23 @@ func Load(name, dir string) (*Baseline, error) {
 	return &b, nil
 }
 
+// LoadLatest reads the baseline in dir with the most recent timestamp, for
+// comparing against the last unattended capture without knowing its name.
+// Unparseable files are skipped.
+func LoadLatest(dir string) (*Baseline, error) {
+	all, err := listStored(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		if dir == "" {
+			dir = DefaultDir()
+		}
+		return nil, fmt.Errorf("no baselines in %s", dir)
+	}
+	return Load(all[0].name, dir)
+}
+
 // List returns all saved baseline names.
 func List(dir string) ([]string, error) {
 	if dir == "" {
@@ -90,6 +108,35 @@ func List(dir string) ([]string, error) {
 	return names, nil
 }
 
+// stored is a saved baseline's file name and capture time.
+type stored struct {
+	name      string
+	timestamp time.Time
+}
+
+// listStored returns the baselines in dir, newest first, by the timestamp in
+// each file rather than its name. Files that can't be parsed are skipped, so
+// one corrupt file doesn't block LoadLatest and pruning never deletes
+// something it doesn't understand.
+func listStored(dir string) ([]stored, error) {
+	names, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	var all []stored
+	for _, name := range names {
+		b, err := Load(name, dir)
+		if err != nil || b.Timestamp.IsZero() {
+			continue
+		}
+		all = append(all, stored{name: name, timestamp: b.Timestamp})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].timestamp.After(all[j].timestamp)
+	})
+	return all, nil
+}
+
 // NewBaseline creates a new baseline from current checks.
 func NewBaseline(name string, checks []use.Check) *Baseline {
 	hostname, _ := os.Hostname()
@@ -100,3 +147,20 @@ func NewBaseline(name string, checks []use.Check) *Baseline {
 		Checks:    checks,
 	}
 }
+
+// NewAutoBaseline creates a baseline named after the host and capture time,
+// e.g. "web-01-20240115-1530", so scheduled captures (from cron, say) don't
+// collide. Captures within the same minute share a name.
+func NewAutoBaseline(checks []use.Check) *Baseline {
+	b := NewBaseline("", checks)
+	b.Name = autoName(b.Hostname, b.Timestamp)
+	return b
+}
+
+// autoName builds a baseline name from a hostname and time.
+func autoName(hostname string, t time.Time) string {
+	if hostname == "" {
+		hostname = "umd"
+	}
+	return hostname + "-" + t.Format("20060102-1504")
+}
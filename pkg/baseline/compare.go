@@ -5,6 +5,7 @@ import (
 	"io"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/danpilch/umd/pkg/use"
@@ -25,10 +26,29 @@ const (
 type Comparison struct {
 	Resource    string
 	Type        use.MetricType
+	Kind        string // use.Check.Kind, e.g. "inodes"
 	BaselineVal float64
 	CurrentVal  float64
 	DeltaPct    float64
+	Sigma       float64 // standard deviations from a statistical baseline's mean; 0 otherwise
 	Severity    Severity
+
+	// BaselineStatus and CurrentStatus are the checks' statuses on each
+	// side, so a small delta that crossed a threshold isn't overlooked.
+	BaselineStatus use.Status
+	CurrentStatus  use.Status
+}
+
+// StatusChanged reports whether the metric's status differs between the two
+// sides. Comparisons missing a status on either side never count as changed.
+func (c Comparison) StatusChanged() bool {
+	return c.BaselineStatus != "" && c.CurrentStatus != "" && c.BaselineStatus != c.CurrentStatus
+}
+
+// StatusWorsened reports whether the metric moved to a worse status, e.g.
+// from OK to WARNING.
+func (c Comparison) StatusWorsened() bool {
+	return c.StatusChanged() && c.CurrentStatus.Worse(c.BaselineStatus)
 }
 
 var (
@@ -41,19 +61,39 @@ var (
 	blMinor   = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
 )
 
-// Compare matches checks by Resource+Type and calculates drift.
+// Label names one side of a comparison in rendered output.
+type Label struct {
+	Name      string
+	Timestamp time.Time
+}
+
+// Label returns the label identifying the baseline in a comparison.
+func (b *Baseline) Label() Label {
+	return Label{Name: b.Name, Timestamp: b.Timestamp}
+}
+
+// CurrentLabel labels live checks collected just now.
+func CurrentLabel() Label {
+	return Label{Name: "current", Timestamp: time.Now()}
+}
+
+// CompareBaselines calculates drift from baseline a to baseline b, e.g. last
+// Tuesday against last Wednesday, without the host being in either state.
+// In the result BaselineVal is a's value and CurrentVal is b's.
+func CompareBaselines(a, b *Baseline) []Comparison {
+	return Compare(a, b.Checks)
+}
+
+// Compare matches checks by use.Check.Key and calculates drift.
 func Compare(baseline *Baseline, current []use.Check) []Comparison {
-	// Index baseline by resource+type
 	baselineMap := make(map[string]use.Check)
 	for _, c := range baseline.Checks {
-		key := c.Resource + "|" + string(c.Type)
-		baselineMap[key] = c
+		baselineMap[c.Key()] = c
 	}
 
 	var comparisons []Comparison
 	for _, cur := range current {
-		key := cur.Resource + "|" + string(cur.Type)
-		base, ok := baselineMap[key]
+		base, ok := baselineMap[cur.Key()]
 		if !ok {
 			continue
 		}
@@ -70,10 +110,14 @@ func Compare(baseline *Baseline, current []use.Check) []Comparison {
 		comparisons = append(comparisons, Comparison{
 			Resource:    cur.Resource,
 			Type:        cur.Type,
+			Kind:        cur.Kind,
 			BaselineVal: base.RawValue,
 			CurrentVal:  cur.RawValue,
 			DeltaPct:    deltaPct,
 			Severity:    sev,
+
+			BaselineStatus: base.Status,
+			CurrentStatus:  cur.Status,
 		})
 	}
 
@@ -97,47 +141,125 @@ func classifySeverity(deltaPct float64) Severity {
 	return SeverityMajor
 }
 
-// RenderComparison outputs a styled comparison table.
-func RenderComparison(w io.Writer, baseline *Baseline, comparisons []Comparison) {
+// Regressions returns comparisons with a Moderate or larger increase, or
+// whose status worsened however small the delta, dropping improvements and
+// minor changes.
+func Regressions(comparisons []Comparison) []Comparison {
+	var result []Comparison
+	for _, c := range comparisons {
+		if c.StatusWorsened() {
+			result = append(result, c)
+			continue
+		}
+		if c.DeltaPct <= 0 {
+			continue
+		}
+		if c.Severity == SeverityModerate || c.Severity == SeverityRegress {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// RenderComparison outputs a styled comparison table from before to after,
+// e.g. a baseline's Label and CurrentLabel, or two baselines' Labels.
+func RenderComparison(w io.Writer, before, after Label, comparisons []Comparison) {
+	renderComparison(w, before, after, comparisons, 0)
+}
+
+// RenderRegressions outputs only regressions, collapsing all other rows into a count.
+func RenderRegressions(w io.Writer, before, after Label, comparisons []Comparison) {
+	regressions := Regressions(comparisons)
+	renderComparison(w, before, after, regressions, len(comparisons)-len(regressions))
+}
+
+// renderComparison outputs the comparison table, noting hidden rows if any.
+func renderComparison(w io.Writer, before, after Label, comparisons []Comparison, hidden int) {
+	bold := lipgloss.NewStyle().Bold(true)
 	fmt.Fprintln(w, blTitle.Render("Baseline Comparison"))
 	fmt.Fprintln(w, blDim.Render(strings.Repeat("═", 90)))
-	fmt.Fprintf(w, "Comparing against %s (from %s)\n\n",
-		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%q", baseline.Name)),
-		blDim.Render(baseline.Timestamp.Format("2006-01-02 15:04:05")))
+	fmt.Fprintf(w, "Comparing %s (from %s) to %s (from %s)\n\n",
+		bold.Render(fmt.Sprintf("%q", before.Name)),
+		blDim.Render(before.Timestamp.Format("2006-01-02 15:04:05")),
+		bold.Render(fmt.Sprintf("%q", after.Name)),
+		blDim.Render(after.Timestamp.Format("2006-01-02 15:04:05")))
 
-	fmt.Fprintf(w, "  %s %s %s %s %s %s\n",
+	// A statistical baseline's distance from the mean is what decides
+	// severity, so it is shown next to the delta
+	hasSigma := false
+	for _, c := range comparisons {
+		if c.Sigma != 0 {
+			hasSigma = true
+			break
+		}
+	}
+	deltaHeader, deltaWidth := "DELTA    ", 10
+	if hasSigma {
+		deltaHeader, deltaWidth = "DELTA (σ)        ", 18
+	}
+
+	fmt.Fprintf(w, "  %s %s %s %s %s %s %s\n",
 		blHeader.Render("RESOURCE                "),
 		blHeader.Render("TYPE          "),
-		blHeader.Render("BASELINE  "),
-		blHeader.Render("CURRENT   "),
-		blHeader.Render("DELTA    "),
-		blHeader.Render("SEVERITY  "))
+		blHeader.Render("BEFORE    "),
+		blHeader.Render("AFTER     "),
+		blHeader.Render(deltaHeader),
+		blHeader.Render("SEVERITY  "),
+		blHeader.Render("STATUS"))
 	fmt.Fprintln(w, "  "+blDim.Render(strings.Repeat("─", 90)))
 
-	regressions := 0
+	regressions, changed, worsened := 0, 0, 0
 	for _, c := range comparisons {
 		deltaStr := fmt.Sprintf("%+.1f%%", c.DeltaPct)
-		var sevStr string
+		if c.Sigma != 0 {
+			deltaStr += fmt.Sprintf(" (%+.1fσ)", c.Sigma)
+		}
+		sevLabel, sevStyle := "none", blOK
 		switch c.Severity {
 		case SeverityRegress:
-			sevStr = blErr.Render("REGRESSION")
+			sevLabel, sevStyle = "REGRESSION", blErr
 			regressions++
 		case SeverityMajor:
-			sevStr = blErr.Render("MAJOR")
+			sevLabel, sevStyle = "MAJOR", blErr
 			regressions++
 		case SeverityModerate:
-			sevStr = blWarn.Render("moderate")
+			sevLabel, sevStyle = "moderate", blWarn
 		case SeverityMinor:
-			sevStr = blMinor.Render("minor")
-		default:
-			sevStr = blOK.Render("none")
+			sevLabel, sevStyle = "minor", blMinor
+		}
+
+		// Status transitions stand out even when the delta is small
+		statusStr := blDim.Render(string(c.CurrentStatus))
+		if c.StatusChanged() {
+			changed++
+			style := blOK
+			if c.StatusWorsened() {
+				worsened++
+				style = blWarn
+				if c.CurrentStatus == use.StatusError {
+					style = blErr
+				}
+			}
+			statusStr = style.Render(fmt.Sprintf("%s → %s", c.BaselineStatus, c.CurrentStatus))
 		}
 
-		fmt.Fprintf(w, "  %-25s %-15s %-12.2f %-12.2f %-10s %s\n",
-			c.Resource, c.Type, c.BaselineVal, c.CurrentVal, deltaStr, sevStr)
+		fmt.Fprintf(w, "  %-25s %-15s %-12.2f %-12.2f %-*s %s %s\n",
+			c.Resource, c.Type, c.BaselineVal, c.CurrentVal, deltaWidth, deltaStr,
+			sevStyle.Render(fmt.Sprintf("%-10s", sevLabel)), statusStr)
+	}
+
+	if hidden > 0 {
+		fmt.Fprintf(w, "  %s\n", blDim.Render(fmt.Sprintf("%d improvements or minor changes hidden.", hidden)))
 	}
 
 	fmt.Fprintln(w)
+	if changed > 0 {
+		style := blOK
+		if worsened > 0 {
+			style = blWarn
+		}
+		fmt.Fprintf(w, "  %s\n", style.Render(fmt.Sprintf("%d checks changed status (%d worsened).", changed, worsened)))
+	}
 	if regressions > 0 {
 		fmt.Fprintf(w, "  %s\n", blErr.Render(fmt.Sprintf("%d potential regressions detected.", regressions)))
 	} else {
@@ -0,0 +1,56 @@
+package baseline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Prune keeps the keep most recent baselines in dir and deletes the rest,
+// returning the names it removed.
+func Prune(dir string, keep int) ([]string, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("keep must not be negative, got %d", keep)
+	}
+	all, err := listStored(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) <= keep {
+		return nil, nil
+	}
+	return remove(dir, all[keep:])
+}
+
+// PruneOlderThan deletes baselines in dir captured more than age ago,
+// returning the names it removed.
+func PruneOlderThan(dir string, age time.Duration) ([]string, error) {
+	all, err := listStored(dir)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-age)
+	var old []stored
+	for _, s := range all {
+		if s.timestamp.Before(cutoff) {
+			old = append(old, s)
+		}
+	}
+	return remove(dir, old)
+}
+
+// remove deletes the given baselines from dir, stopping at the first error.
+func remove(dir string, baselines []stored) ([]string, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	var removed []string
+	for _, s := range baselines {
+		if err := os.Remove(filepath.Join(dir, s.name+".json")); err != nil {
+			return removed, fmt.Errorf("cannot remove baseline %q: %w", s.name, err)
+		}
+		removed = append(removed, s.name)
+	}
+	return removed, nil
+}
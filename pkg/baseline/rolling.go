@@ -0,0 +1,102 @@
+package baseline
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// RollingStats maintains an in-memory, exponentially weighted mean and
+// standard deviation per metric, giving a self-calibrating baseline during
+// long watch sessions without a pre-saved snapshot.
+type RollingStats struct {
+	mu    sync.Mutex
+	stats map[string]*rollingStat
+
+	Window     int     // effective sample window for the moving average
+	MinSamples int     // samples required before anomalies are reported
+	ZThreshold float64 // standard deviations from the mean considered anomalous
+}
+
+type rollingStat struct {
+	n        int
+	mean     float64
+	variance float64
+}
+
+// NewRollingStats creates a rolling baseline with sensible defaults.
+func NewRollingStats() *RollingStats {
+	return &RollingStats{
+		stats:      make(map[string]*rollingStat),
+		Window:     60,
+		MinSamples: 5,
+		ZThreshold: 3.0,
+	}
+}
+
+func rollingKey(c use.Check) string {
+	return c.Key()
+}
+
+// Update folds the current checks into the rolling baseline.
+// Checks with unknown status are ignored.
+func (r *RollingStats) Update(checks []use.Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alpha := 2.0 / (float64(r.Window) + 1)
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			continue
+		}
+		key := rollingKey(c)
+		s, ok := r.stats[key]
+		if !ok {
+			r.stats[key] = &rollingStat{n: 1, mean: c.RawValue}
+			continue
+		}
+		diff := c.RawValue - s.mean
+		incr := alpha * diff
+		s.mean += incr
+		s.variance = (1 - alpha) * (s.variance + diff*incr)
+		s.n++
+	}
+}
+
+// ZScore returns how many standard deviations the check's value is from the
+// rolling mean, and false if there is not yet enough history.
+func (r *RollingStats) ZScore(c use.Check) (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.stats[rollingKey(c)]
+	if !ok || s.n < r.MinSamples {
+		return 0, false
+	}
+	std := math.Sqrt(s.variance)
+	if std == 0 {
+		if c.RawValue == s.mean {
+			return 0, true
+		}
+		return math.Inf(int(math.Copysign(1, c.RawValue-s.mean))), true
+	}
+	return (c.RawValue - s.mean) / std, true
+}
+
+// Anomaly returns a short label such as "+3.4σ" when the check deviates
+// anomalously from the session's norm, or "" otherwise.
+func (r *RollingStats) Anomaly(c use.Check) string {
+	if c.Status == use.StatusUnknown {
+		return ""
+	}
+	z, ok := r.ZScore(c)
+	if !ok || math.Abs(z) < r.ZThreshold {
+		return ""
+	}
+	if math.IsInf(z, 0) {
+		return "changed"
+	}
+	return fmt.Sprintf("%+.1fσ", z)
+}
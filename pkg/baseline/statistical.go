@@ -0,0 +1,215 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// SigmaThreshold is how many standard deviations from the baseline mean a
+// value must be before a statistical comparison flags it.
+const SigmaThreshold = 2.0
+
+// MetricStats summarizes one metric over a statistical baseline's captures.
+type MetricStats struct {
+	Resource string         `json:"resource"`
+	Type     use.MetricType `json:"type"`
+	Kind     string         `json:"kind,omitempty"`
+	Mean     float64        `json:"mean"`
+	StdDev   float64        `json:"stddev"`
+	Min      float64        `json:"min"`
+	Max      float64        `json:"max"`
+	N        int            `json:"n"`
+}
+
+// Key identifies the metric the same way use.Check.Key does.
+func (m MetricStats) Key() string {
+	return use.Check{Resource: m.Resource, Type: m.Type, Kind: m.Kind}.Key()
+}
+
+// StatisticalBaseline is a baseline built from several captures, storing the
+// mean and standard deviation of each metric so bursty metrics are compared
+// against their normal band rather than a single reading. Its Checks hold the
+// mean values, so it also loads and compares as a plain Baseline.
+type StatisticalBaseline struct {
+	Baseline
+	Samples  int           `json:"samples"`
+	Interval time.Duration `json:"interval"`
+	Stats    []MetricStats `json:"stats"`
+}
+
+// CaptureN runs the collectors n times, interval apart, and summarizes each
+// metric. Unknown checks are left out of the statistics. Set Name before
+// saving.
+func CaptureN(collectors []use.Collector, thresholds use.Thresholds, n int, interval time.Duration) *StatisticalBaseline {
+	if n < 1 {
+		n = 1
+	}
+	checker := use.NewChecker(thresholds, nil)
+
+	type acc struct {
+		stats MetricStats
+		m2    float64
+		check use.Check
+	}
+	accs := make(map[string]*acc)
+	var order []string
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		for _, c := range checker.RunAll(collectors) {
+			if c.Status == use.StatusUnknown {
+				continue
+			}
+			key := c.Key()
+			a, ok := accs[key]
+			if !ok {
+				a = &acc{stats: MetricStats{Resource: c.Resource, Type: c.Type, Kind: c.Kind, Min: c.RawValue, Max: c.RawValue}}
+				accs[key] = a
+				order = append(order, key)
+			}
+			// Welford's online mean and variance
+			a.stats.N++
+			diff := c.RawValue - a.stats.Mean
+			a.stats.Mean += diff / float64(a.stats.N)
+			a.m2 += diff * (c.RawValue - a.stats.Mean)
+			a.stats.Min = math.Min(a.stats.Min, c.RawValue)
+			a.stats.Max = math.Max(a.stats.Max, c.RawValue)
+			a.check = c
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	sb := &StatisticalBaseline{
+		Baseline: Baseline{
+			Timestamp: time.Now(),
+			Hostname:  hostname,
+		},
+		Samples:  n,
+		Interval: interval,
+	}
+	for _, key := range order {
+		a := accs[key]
+		if a.stats.N > 1 {
+			a.stats.StdDev = math.Sqrt(a.m2 / float64(a.stats.N-1))
+		}
+		sb.Stats = append(sb.Stats, a.stats)
+
+		mean := a.check
+		mean.RawValue = a.stats.Mean
+		mean.Value = fmt.Sprintf("%.2f (mean of %d)", a.stats.Mean, a.stats.N)
+		sb.Checks = append(sb.Checks, mean)
+	}
+	return sb
+}
+
+// Save writes the statistical baseline alongside regular baselines.
+func (s *StatisticalBaseline) Save(dir string) error {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("cannot create baseline directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal baseline: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, s.Name+".json"), data, 0644); err != nil {
+		return fmt.Errorf("cannot write baseline: %w", err)
+	}
+	return nil
+}
+
+// LoadStatistical reads a statistical baseline. A baseline saved from a
+// single capture has no stats and is reported as an error.
+func LoadStatistical(name, dir string) (*StatisticalBaseline, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline %q: %w", name, err)
+	}
+
+	var s StatisticalBaseline
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("cannot parse baseline: %w", err)
+	}
+	if len(s.Stats) == 0 {
+		return nil, fmt.Errorf("baseline %q is a single capture, not a statistical baseline", name)
+	}
+	return &s, nil
+}
+
+// Compare matches checks against the baseline's per-metric band. A value
+// more than SigmaThreshold standard deviations above the mean is a
+// regression, and as far below is a major (improving) change; anything
+// inside the band is SeverityNone. Metrics that never varied during capture
+// fall back to percentage-based severity.
+func (s *StatisticalBaseline) Compare(current []use.Check) []Comparison {
+	stats := make(map[string]MetricStats, len(s.Stats))
+	for _, m := range s.Stats {
+		stats[m.Key()] = m
+	}
+
+	// The stored checks carry each metric's status at capture time
+	statuses := make(map[string]use.Status, len(s.Checks))
+	for _, c := range s.Checks {
+		statuses[c.Key()] = c.Status
+	}
+
+	var comparisons []Comparison
+	for _, cur := range current {
+		m, ok := stats[cur.Key()]
+		if !ok || cur.Status == use.StatusUnknown {
+			continue
+		}
+
+		var deltaPct float64
+		if m.Mean != 0 {
+			deltaPct = ((cur.RawValue - m.Mean) / math.Abs(m.Mean)) * 100
+		} else if cur.RawValue != 0 {
+			deltaPct = 100
+		}
+
+		var sev Severity
+		var sigma float64
+		if m.StdDev > 0 {
+			sigma = (cur.RawValue - m.Mean) / m.StdDev
+			switch {
+			case sigma > SigmaThreshold:
+				sev = SeverityRegress
+			case sigma < -SigmaThreshold:
+				sev = SeverityMajor
+			default:
+				sev = SeverityNone
+			}
+		} else {
+			sev = classifySeverity(deltaPct)
+		}
+
+		comparisons = append(comparisons, Comparison{
+			Resource:    cur.Resource,
+			Type:        cur.Type,
+			Kind:        cur.Kind,
+			BaselineVal: m.Mean,
+			CurrentVal:  cur.RawValue,
+			DeltaPct:    deltaPct,
+			Sigma:       sigma,
+			Severity:    sev,
+
+			BaselineStatus: statuses[cur.Key()],
+			CurrentStatus:  cur.Status,
+		})
+	}
+	return comparisons
+}
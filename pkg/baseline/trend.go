@@ -0,0 +1,182 @@
+package baseline
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+	"github.com/sirupsen/logrus"
+)
+
+// TrendPoint is one metric value from a saved baseline.
+type TrendPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// LoadAll loads every saved baseline in dir, oldest first. Files that don't
+// parse as baselines are logged and skipped, as Latest and Prune skip them,
+// so one corrupt file doesn't hide the whole trend.
+func LoadAll(dir string) ([]*Baseline, error) {
+	names, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	baselines := make([]*Baseline, 0, len(names))
+	for _, name := range names {
+		b, err := Load(name, dir)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"baseline": name,
+				"error":    err,
+			}).Warn("Skipping unreadable baseline")
+			continue
+		}
+		baselines = append(baselines, b)
+	}
+	sort.SliceStable(baselines, func(i, j int) bool {
+		return baselines[i].Timestamp.Before(baselines[j].Timestamp)
+	})
+	return baselines, nil
+}
+
+// Trend returns the time-ordered values of one metric, identified by its
+// check key, across baselines. Baselines without the metric, or where it
+// was unknown, are skipped.
+func Trend(baselines []*Baseline, key string) []TrendPoint {
+	var points []TrendPoint
+	for _, b := range baselines {
+		for _, c := range b.Checks {
+			if c.Key() == key && c.Status != use.StatusUnknown {
+				points = append(points, TrendPoint{Timestamp: b.Timestamp, Value: c.RawValue})
+				break
+			}
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+	return points
+}
+
+// RenderTrend outputs a sparkline per metric across baselines, with the
+// first and last values and the overall change, so slow creep stands out.
+// Metrics present in fewer than two baselines are omitted.
+func RenderTrend(w io.Writer, baselines []*Baseline) {
+	fmt.Fprintln(w, blTitle.Render("Baseline Trend"))
+	fmt.Fprintln(w, blDim.Render(strings.Repeat("═", 90)))
+	if len(baselines) < 2 {
+		fmt.Fprintln(w, "At least two baselines are needed to show a trend.")
+		return
+	}
+
+	first, last := baselines[0].Timestamp, baselines[0].Timestamp
+	for _, b := range baselines {
+		if b.Timestamp.Before(first) {
+			first = b.Timestamp
+		}
+		if b.Timestamp.After(last) {
+			last = b.Timestamp
+		}
+	}
+	fmt.Fprintf(w, "%d baselines from %s to %s\n\n", len(baselines),
+		blDim.Render(first.Format("2006-01-02 15:04")), blDim.Render(last.Format("2006-01-02 15:04")))
+
+	fmt.Fprintf(w, "  %s %s %s %s %s %s\n",
+		blHeader.Render("RESOURCE                "),
+		blHeader.Render("TYPE          "),
+		blHeader.Render("TREND                 "),
+		blHeader.Render("FIRST     "),
+		blHeader.Render("LAST      "),
+		blHeader.Render("CHANGE  "))
+	fmt.Fprintln(w, "  "+blDim.Render(strings.Repeat("─", 90)))
+
+	// Metrics in first-seen order
+	type metric struct {
+		resource string
+		mtype    string
+		key      string
+	}
+	seen := make(map[string]bool)
+	var metrics []metric
+	for _, b := range baselines {
+		for _, c := range b.Checks {
+			if seen[c.Key()] {
+				continue
+			}
+			seen[c.Key()] = true
+			mtype := string(c.Type)
+			if c.Kind != "" {
+				mtype += " (" + c.Kind + ")"
+			}
+			metrics = append(metrics, metric{c.Resource, mtype, c.Key()})
+		}
+	}
+
+	for _, m := range metrics {
+		points := Trend(baselines, m.key)
+		if len(points) < 2 {
+			continue
+		}
+		values := make([]float64, len(points))
+		for i, p := range points {
+			values[i] = p.Value
+		}
+		from, to := values[0], values[len(values)-1]
+
+		change := blDim.Render("n/a")
+		if from != 0 {
+			pct := (to - from) / from * 100
+			s := fmt.Sprintf("%+.1f%%", pct)
+			switch sev := classifySeverity(pct); {
+			case sev == SeverityMajor || sev == SeverityRegress:
+				change = blErr.Render(s)
+			case sev == SeverityModerate:
+				change = blWarn.Render(s)
+			default:
+				change = s
+			}
+		}
+
+		fmt.Fprintf(w, "  %-25s %-15s %-23s %-11.2f %-11.2f %s\n",
+			m.resource, m.mtype, trendSparkline(values, 22), from, to, change)
+	}
+}
+
+var trendBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// trendSparkline draws values as block characters, downsampling by averaging
+// when there are more values than width.
+func trendSparkline(values []float64, width int) string {
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			lo, hi := i*len(values)/width, (i+1)*len(values)/width
+			var sum float64
+			for _, v := range values[lo:hi] {
+				sum += v
+			}
+			buckets[i] = sum / float64(hi-lo)
+		}
+		values = buckets
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > min {
+			idx = int((v - min) / (max - min) * float64(len(trendBlocks)-1))
+		}
+		sb.WriteRune(trendBlocks[idx])
+	}
+	return sb.String()
+}
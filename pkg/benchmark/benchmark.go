@@ -56,9 +56,11 @@ func Run(collectors []use.Collector, thresholds use.Thresholds, opts Options) []
 	var results []Result
 
 	for _, col := range collectors {
+		colThresholds := thresholds.ForResource(col.Name())
+
 		// Warmup
 		for i := 0; i < opts.Warmup; i++ {
-			col.Collect(thresholds)
+			col.Collect(colThresholds)
 		}
 
 		// Benchmark
@@ -67,7 +69,7 @@ func Run(collectors []use.Collector, thresholds use.Thresholds, opts Options) []
 
 		for i := 0; i < opts.Iterations; i++ {
 			start := time.Now()
-			checks, err := col.Collect(thresholds)
+			checks, err := col.Collect(colThresholds)
 			latencies[i] = time.Since(start)
 
 			if err == nil {
@@ -0,0 +1,101 @@
+// Package cache persists recent check results so lightweight surfaces can avoid re-running collection.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// ErrStale is returned when a cached run is older than the allowed age.
+var ErrStale = errors.New("cached run is stale")
+
+// LastRun is the most recent set of check results.
+type LastRun struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Checks    []use.Check `json:"checks"`
+}
+
+// StateDir returns the directory used for umd state between runs.
+func StateDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".umd/state"
+	}
+	return filepath.Join(home, ".umd", "state")
+}
+
+// DefaultPath returns the default last-run cache file path.
+func DefaultPath() string {
+	return filepath.Join(StateDir(), "last_run.json")
+}
+
+// Recorder writes each run to the last-run cache at Path (DefaultPath when
+// empty), so the prompt and time-to-full projection read the latest run.
+// Pass it to use.Checker.SetRecorder, alongside history.Recorder with
+// use.Recorders.
+type Recorder struct {
+	Path string
+}
+
+// Record replaces the last-run cache with checks taken at the given time.
+func (r Recorder) Record(checks []use.Check, at time.Time) error {
+	return save(r.Path, checks, at)
+}
+
+// Save writes checks to the last-run cache.
+func Save(path string, checks []use.Check) error {
+	return save(path, checks, time.Now())
+}
+
+// save writes checks taken at the given time to the last-run cache.
+func save(path string, checks []use.Check, at time.Time) error {
+	if path == "" {
+		path = DefaultPath()
+	}
+	data, err := json.Marshal(LastRun{Timestamp: at, Checks: checks})
+	if err != nil {
+		return fmt.Errorf("cannot marshal last run: %w", err)
+	}
+	return WriteFile(path, data)
+}
+
+// WriteFile writes a state file, creating its directory. It writes to a
+// temp file and renames it into place, so concurrent readers never see a
+// partial file and a run that dies mid-write leaves the old one intact.
+func WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("cannot create state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("cannot write %s: %w", filepath.Base(path), err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the last-run cache. If maxAge is positive and the cached run is
+// older than maxAge, the run is returned along with ErrStale.
+func Load(path string, maxAge time.Duration) (*LastRun, error) {
+	if path == "" {
+		path = DefaultPath()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read last run: %w", err)
+	}
+
+	var run LastRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("cannot parse last run: %w", err)
+	}
+	if maxAge > 0 && time.Since(run.Timestamp) > maxAge {
+		return &run, ErrStale
+	}
+	return &run, nil
+}
@@ -0,0 +1,19 @@
+// Package audit provides Linux audit subsystem backlog metrics for the USE method.
+package audit
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers audit backlog saturation and lost-event metrics.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new audit collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "Audit"
+}
@@ -0,0 +1,10 @@
+//go:build darwin
+
+package audit
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which has no Linux audit backlog.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// auditStatus holds the fields of `auditctl -s` that matter for saturation.
+type auditStatus struct {
+	Enabled      uint64
+	Backlog      uint64
+	BacklogLimit uint64
+	Lost         uint64
+	WaitActual   uint64 // backlog_wait_time_actual: time syscalls spent blocked
+	Raw          string
+}
+
+// Collect gathers audit backlog metrics on Linux. A full backlog blocks every
+// audited syscall, slowing the whole system while no resource looks busy.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	s1, err := readAuditStatus()
+	if err != nil {
+		return unknownChecks(err), nil
+	}
+	if s1.Enabled == 0 {
+		// Auditing is off; there is no backlog to fill
+		return nil, nil
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	s2, err := readAuditStatus()
+	if err != nil {
+		return unknownChecks(err), nil
+	}
+	c.Trace(c.Name(), "auditctl -s", s1.Raw+" -> "+s2.Raw, float64(s2.Backlog))
+
+	// Saturation: backlog as a share of backlog_limit, and whether it is growing
+	var pct float64
+	if s2.BacklogLimit > 0 {
+		pct = float64(s2.Backlog) / float64(s2.BacklogLimit) * 100
+	}
+	satStatus := thresholds.EvaluateSaturationFor("Audit", use.Saturation, pct)
+	if s2.Backlog > s1.Backlog && satStatus == use.StatusOK {
+		satStatus = use.StatusWarning
+	}
+	if s2.WaitActual > s1.WaitActual {
+		satStatus = use.StatusError
+	}
+	desc := fmt.Sprintf("Audit backlog vs backlog_limit %d", s2.BacklogLimit)
+	switch satStatus {
+	case use.StatusWarning:
+		desc = fmt.Sprintf("Audit backlog growing (%d -> %d of %d); audited syscalls block when full", s1.Backlog, s2.Backlog, s2.BacklogLimit)
+	case use.StatusError:
+		desc = fmt.Sprintf("Audit backlog full or blocking syscalls (%d of %d, backlog_wait_time_actual %d)", s2.Backlog, s2.BacklogLimit, s2.WaitActual)
+	}
+
+	checks := []use.Check{{
+		Resource:    "Audit",
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("%d/%d backlog", s2.Backlog, s2.BacklogLimit),
+		RawValue:    pct,
+		Unit:        use.UnitPercent,
+		Status:      satStatus,
+		Description: desc,
+		Command:     "auditctl -s",
+	}}
+
+	// Errors: events dropped during the sample window. The lost counter is
+	// cumulative since auditd started, so old drops would otherwise keep the
+	// check failing forever; a counter reset (auditd restarted) reads as 0.
+	var lost uint64
+	if s2.Lost > s1.Lost {
+		lost = s2.Lost - s1.Lost
+	}
+	c.Trace(c.Name(), "auditctl -s", fmt.Sprintf("lost %d -> %d", s1.Lost, s2.Lost), float64(lost))
+	checks = append(checks, use.Check{
+		Resource:    "Audit",
+		Type:        use.Errors,
+		Value:       fmt.Sprintf("%d lost", lost),
+		RawValue:    float64(lost),
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
+		Label:       "lost",
+		Status:      use.EvaluateErrors(int64(lost)),
+		Description: fmt.Sprintf("Audit events lost during sample (backlog overflow or rate limit); %d lost in total", s2.Lost),
+		Command:     "auditctl -s",
+	})
+
+	return checks, nil
+}
+
+// unknownChecks reports the audit backlog as unknown when auditctl is missing
+// or can't query the kernel (it requires root).
+func unknownChecks(err error) []use.Check {
+	return []use.Check{{
+		Resource:    "Audit",
+		Type:        use.Saturation,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: err.Error(),
+		Command:     "auditctl -s",
+	}}
+}
+
+// readAuditStatus runs `auditctl -s` and parses its key/value output.
+func readAuditStatus() (auditStatus, error) {
+	if _, err := exec.LookPath("auditctl"); err != nil {
+		return auditStatus{}, fmt.Errorf("auditd not installed (auditctl not found)")
+	}
+	out, err := exec.Command("auditctl", "-s").Output()
+	if err != nil {
+		return auditStatus{}, fmt.Errorf("auditctl -s failed (requires root): %w", err)
+	}
+
+	var s auditStatus
+	var raw []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "enabled":
+			s.Enabled = v
+		case "backlog":
+			s.Backlog = v
+		case "backlog_limit":
+			s.BacklogLimit = v
+		case "lost":
+			s.Lost = v
+		case "backlog_wait_time_actual":
+			s.WaitActual = v
+		default:
+			continue
+		}
+		raw = append(raw, fields[0]+"="+fields[1])
+	}
+	s.Raw = strings.Join(raw, " ")
+	return s, scanner.Err()
+}
+
+// Preflight reports whether auditctl can query the kernel audit status.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.RunAccess("auditctl", "-s")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "auditctl -s",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "Audit backlog and lost events reported as unknown",
+	}}
+}
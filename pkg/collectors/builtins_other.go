@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+
+package collectors
+
+import (
+	"github.com/danpilch/umd/pkg/collectors/cpu"
+	"github.com/danpilch/umd/pkg/collectors/disk"
+	"github.com/danpilch/umd/pkg/collectors/memory"
+)
+
+// builtins maps manifest names to built-in collector constructors. Only the
+// CPU, memory and disk collectors are ported to Windows and FreeBSD; naming
+// any other built-in in a manifest there is an unknown-collector error.
+var builtins = map[string]func(e ManifestEntry) Collector{
+	"cpu":    func(ManifestEntry) Collector { return cpu.New() },
+	"memory": func(ManifestEntry) Collector { return memory.New() },
+	"disk":   func(ManifestEntry) Collector { return disk.New() },
+}
@@ -0,0 +1,53 @@
+//go:build linux || darwin
+
+package collectors
+
+import (
+	"github.com/danpilch/umd/pkg/collectors/audit"
+	"github.com/danpilch/umd/pkg/collectors/cgroup"
+	"github.com/danpilch/umd/pkg/collectors/cpu"
+	"github.com/danpilch/umd/pkg/collectors/disk"
+	"github.com/danpilch/umd/pkg/collectors/entropy"
+	"github.com/danpilch/umd/pkg/collectors/filesystem"
+	"github.com/danpilch/umd/pkg/collectors/gpu"
+	"github.com/danpilch/umd/pkg/collectors/irq"
+	"github.com/danpilch/umd/pkg/collectors/membw"
+	"github.com/danpilch/umd/pkg/collectors/memory"
+	"github.com/danpilch/umd/pkg/collectors/network"
+	"github.com/danpilch/umd/pkg/collectors/numa"
+	"github.com/danpilch/umd/pkg/collectors/psi"
+	"github.com/danpilch/umd/pkg/collectors/scheduler"
+	"github.com/danpilch/umd/pkg/collectors/systemd"
+	"github.com/danpilch/umd/pkg/collectors/tcp"
+	"github.com/danpilch/umd/pkg/collectors/thermal"
+	"github.com/danpilch/umd/pkg/collectors/vmem"
+)
+
+// builtins maps manifest names to built-in collector constructors. Every
+// collector has a Linux and a macOS implementation.
+var builtins = map[string]func(e ManifestEntry) Collector{
+	"cpu":        func(e ManifestEntry) Collector { return &cpu.Collector{PerCore: e.PerCore} },
+	"memory":     func(ManifestEntry) Collector { return memory.New() },
+	"disk":       func(e ManifestEntry) Collector { return &disk.Collector{Iostat: e.Iostat} },
+	"network":    func(ManifestEntry) Collector { return network.New() },
+	"scheduler":  func(ManifestEntry) Collector { return scheduler.New() },
+	"tcp":        func(ManifestEntry) Collector { return tcp.New() },
+	"vmem":       func(ManifestEntry) Collector { return vmem.New() },
+	"filesystem": func(ManifestEntry) Collector { return filesystem.New() },
+	"systemd": func(e ManifestEntry) Collector {
+		c := systemd.New()
+		if len(e.CriticalUnits) > 0 {
+			c.CriticalUnits = e.CriticalUnits
+		}
+		return c
+	},
+	"membw":   func(ManifestEntry) Collector { return membw.New() },
+	"gpu":     func(ManifestEntry) Collector { return gpu.New() },
+	"audit":   func(ManifestEntry) Collector { return audit.New() },
+	"psi":     func(ManifestEntry) Collector { return psi.New() },
+	"numa":    func(ManifestEntry) Collector { return numa.New() },
+	"cgroup":  func(ManifestEntry) Collector { return cgroup.New() },
+	"irq":     func(ManifestEntry) Collector { return irq.New() },
+	"entropy": func(ManifestEntry) Collector { return entropy.New() },
+	"thermal": func(ManifestEntry) Collector { return thermal.New() },
+}
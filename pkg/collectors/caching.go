@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// CachingCollector wraps a collector and reuses its last result for TTL, so
+// callers that overlap (a watch loop and Prometheus scrapes) don't each pay
+// for a fresh sample. Concurrent calls during a collection wait for it rather
+// than starting another. Failed collections are not cached.
+//
+// CachingCollector is a use.ContextCollector: a collection runs under the
+// context of the call that started it, so the checker's timeout reaches the
+// wrapped collector's subprocesses, and a waiting call returns as soon as
+// its own context is done instead of queueing behind a hung collection.
+//
+// Statuses are evaluated with the thresholds of the call that collected, so
+// a cached result ignores different thresholds passed within the window.
+type CachingCollector struct {
+	inner Collector
+	TTL   time.Duration
+
+	mu       sync.Mutex
+	checks   []use.Check
+	at       time.Time
+	inflight *cachedCall
+}
+
+// cachedCall is a collection in progress; done is closed once checks and
+// err are set.
+type cachedCall struct {
+	done   chan struct{}
+	checks []use.Check
+	err    error
+}
+
+// NewCachingCollector wraps c so results are reused for ttl.
+func NewCachingCollector(c Collector, ttl time.Duration) *CachingCollector {
+	return &CachingCollector{
+		inner: c,
+		TTL:   ttl,
+	}
+}
+
+// Name returns the wrapped collector's name.
+func (c *CachingCollector) Name() string {
+	return c.inner.Name()
+}
+
+// SetTracer forwards the tracer to the wrapped collector if it supports tracing.
+func (c *CachingCollector) SetTracer(tr use.Tracer) {
+	if tc, ok := c.inner.(use.Traceable); ok {
+		tc.SetTracer(tr)
+	}
+}
+
+// SetCounterMode forwards the counter mode to the wrapped collector if supported.
+func (c *CachingCollector) SetCounterMode(m use.CounterMode) {
+	if cm, ok := c.inner.(use.CounterModal); ok {
+		cm.SetCounterMode(m)
+	}
+}
+
+// Preflight forwards to the wrapped collector if it supports preflight checks.
+func (c *CachingCollector) Preflight() []use.Access {
+	if p, ok := c.inner.(use.Preflighter); ok {
+		return p.Preflight()
+	}
+	return nil
+}
+
+// Collect returns a copy of the cached checks if they are younger than TTL,
+// otherwise runs the wrapped collector and caches its result.
+func (c *CachingCollector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but gives up waiting once ctx is done. A
+// collection it starts runs the wrapped collector with ctx.
+func (c *CachingCollector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
+	c.mu.Lock()
+	if c.checks != nil && time.Since(c.at) < c.TTL {
+		checks := append([]use.Check(nil), c.checks...)
+		c.mu.Unlock()
+		return checks, nil
+	}
+	call := c.inflight
+	if call == nil {
+		call = &cachedCall{done: make(chan struct{})}
+		c.inflight = call
+		go c.run(ctx, call, thresholds)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return append([]use.Check(nil), call.checks...), call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run collects from the wrapped collector, caches a successful result and
+// releases everyone waiting on call. It runs on its own goroutine, outside
+// the checker's panic recovery, so a panic becomes the call's error.
+func (c *CachingCollector) run(ctx context.Context, call *cachedCall, thresholds use.Thresholds) {
+	var checks []use.Check
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("collector panicked: %v", r)
+			}
+		}()
+		checks, err = use.CollectContext(ctx, c.inner, thresholds)
+	}()
+
+	c.mu.Lock()
+	if err != nil {
+		c.checks = nil
+	} else {
+		c.checks = append([]use.Check{}, checks...)
+		c.at = time.Now()
+	}
+	c.inflight = nil
+	c.mu.Unlock()
+
+	call.checks, call.err = checks, err
+	close(call.done)
+}
+
+// Invalidate drops the cached result so the next Collect samples afresh.
+func (c *CachingCollector) Invalidate() {
+	c.mu.Lock()
+	c.checks = nil
+	c.mu.Unlock()
+}
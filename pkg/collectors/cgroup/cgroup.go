@@ -0,0 +1,21 @@
+// Package cgroup provides cgroup v2 container resource metrics for the USE
+// method.
+package cgroup
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers memory, CPU and I/O metrics for the cgroup umd runs in,
+// so limits are judged against the container rather than the host.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new cgroup collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "Cgroup"
+}
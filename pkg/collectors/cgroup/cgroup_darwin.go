@@ -0,0 +1,10 @@
+//go:build darwin
+
+package cgroup
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which has no cgroups.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
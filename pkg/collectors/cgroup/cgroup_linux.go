@@ -0,0 +1,287 @@
+//go:build linux
+
+package cgroup
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// Collect gathers cgroup v2 USE metrics on Linux. It returns no checks on
+// cgroup v1 hosts or when umd runs in a cgroup with no memory or CPU limit
+// (e.g. directly on the host), where the host collectors already apply.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	dir, ok := cgroupDir()
+	if !ok {
+		return nil, nil
+	}
+	memMax, memLimited := readLimit(filepath.Join(dir, "memory.max"))
+	quota, period, cpuLimited := readCPUMax(filepath.Join(dir, "cpu.max"))
+	if !memLimited && !cpuLimited {
+		return nil, nil
+	}
+
+	cpu1, cpuErr := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	io1, _ := readIOStat(filepath.Join(dir, "io.stat"))
+	start := time.Now()
+	time.Sleep(100 * time.Millisecond)
+	elapsed := time.Since(start)
+	cpu2, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if cpuErr == nil {
+		cpuErr = err
+	}
+	io2, ioErr := readIOStat(filepath.Join(dir, "io.stat"))
+
+	checks := make([]use.Check, 0, 6)
+
+	// Utilization: working set (memory.current less inactive page cache, as
+	// the kubelet computes it) against memory.max
+	if memLimited {
+		path := filepath.Join(dir, "memory.current")
+		current, err := readUint(path)
+		if err != nil {
+			checks = append(checks, unknownCheck("Cgroup (memory)", use.Utilization, err, "cat "+path))
+		} else {
+			stat, _ := readKeyValues(filepath.Join(dir, "memory.stat"))
+			workingSet := current - min(stat["inactive_file"], current)
+			util := float64(workingSet) / float64(memMax) * 100
+			c.Trace(c.Name(), path, fmt.Sprintf("memory.current %d, inactive_file %d, memory.max %d", current, stat["inactive_file"], memMax), util)
+			checks = append(checks, use.Check{
+				Resource:    "Cgroup (memory)",
+				Type:        use.Utilization,
+				Value:       fmt.Sprintf("%.1f%%", util),
+				RawValue:    util,
+				Unit:        use.UnitPercent,
+				ShowsRaw:    true,
+				Status:      thresholds.EvaluateUtilizationFor("Cgroup (memory)", util),
+				Description: fmt.Sprintf("Container working set (%d MB of %d MB memory.max)", workingSet>>20, memMax>>20),
+				Command:     "cat " + path,
+			})
+		}
+	}
+
+	if cpuErr != nil {
+		checks = append(checks, unknownCheck("Cgroup (cpu)", use.Saturation, cpuErr, "cat "+filepath.Join(dir, "cpu.stat")))
+	} else {
+		// Utilization: CPU time used against the cpu.max quota
+		if cpuLimited {
+			usage := cpu2["usage_usec"] - cpu1["usage_usec"]
+			allowed := float64(quota) / float64(period) * float64(elapsed.Microseconds())
+			var util float64
+			if allowed > 0 {
+				util = float64(usage) / allowed * 100
+			}
+			c.Trace(c.Name(), filepath.Join(dir, "cpu.stat"), fmt.Sprintf("usage_usec +%d, cpu.max %d %d", usage, quota, period), util)
+			checks = append(checks, use.Check{
+				Resource:    "Cgroup (cpu)",
+				Type:        use.Utilization,
+				Value:       fmt.Sprintf("%.1f%%", util),
+				RawValue:    util,
+				Unit:        use.UnitPercent,
+				ShowsRaw:    true,
+				Status:      thresholds.EvaluateUtilizationFor("Cgroup (cpu)", util),
+				Description: fmt.Sprintf("CPU used of cpu.max quota (%.2f CPUs)", float64(quota)/float64(period)),
+				Command:     "cat " + filepath.Join(dir, "cpu.stat"),
+			})
+		}
+
+		// Saturation: share of scheduler periods in the sample window in which
+		// the quota ran out. The counters are cumulative since the cgroup was
+		// created, so their ratio alone would report long-past throttling.
+		var throttledPct float64
+		periods := counterDelta(cpu1["nr_periods"], cpu2["nr_periods"])
+		throttled := counterDelta(cpu1["nr_throttled"], cpu2["nr_throttled"])
+		throttledUsec := counterDelta(cpu1["throttled_usec"], cpu2["throttled_usec"])
+		if periods > 0 {
+			throttledPct = min(float64(throttled)/float64(periods)*100, 100)
+		}
+		c.Trace(c.Name(), filepath.Join(dir, "cpu.stat"), fmt.Sprintf("nr_throttled +%d, nr_periods +%d, throttled_usec +%d",
+			throttled, periods, throttledUsec), throttledPct)
+		checks = append(checks, use.Check{
+			Resource: "Cgroup (cpu)",
+			Type:     use.Saturation,
+			Value:    fmt.Sprintf("%.1f%% throttled", throttledPct),
+			RawValue: throttledPct,
+			Unit:     use.UnitPercent,
+			ShowsRaw: true,
+			Label:    "throttled",
+			Status:   thresholds.EvaluateSaturationFor("Cgroup (cpu)", use.Saturation, throttledPct),
+			Description: fmt.Sprintf("Periods throttled by cpu.max during sample (%d of %d, %.1fms throttled; %d of %d since creation)",
+				throttled, periods, float64(throttledUsec)/1e3, cpu2["nr_throttled"], cpu2["nr_periods"]),
+			Command: "cat " + filepath.Join(dir, "cpu.stat"),
+		})
+	}
+
+	// Utilization: I/O throughput across devices
+	if ioErr == nil && io1 != nil {
+		read := io2["rbytes"] - io1["rbytes"]
+		written := io2["wbytes"] - io1["wbytes"]
+		rate := use.PerSecond(read+written, elapsed)
+		c.Trace(c.Name(), filepath.Join(dir, "io.stat"), fmt.Sprintf("rbytes +%d, wbytes +%d", read, written), rate)
+		checks = append(checks, use.Check{
+			Resource:    "Cgroup (io)",
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.0f B/s", rate),
+			RawValue:    rate,
+			Unit:        use.UnitBytesPerSecond,
+			ShowsRaw:    true,
+			Status:      use.StatusOK,
+			Description: fmt.Sprintf("Container I/O throughput (read %.0f B/s, write %.0f B/s)", use.PerSecond(read, elapsed), use.PerSecond(written, elapsed)),
+			Command:     "cat " + filepath.Join(dir, "io.stat"),
+		})
+	}
+
+	// Errors: OOM kills inside the cgroup
+	eventsPath := filepath.Join(dir, "memory.events")
+	events, err := readKeyValues(eventsPath)
+	if err != nil {
+		checks = append(checks, unknownCheck("Cgroup (memory)", use.Errors, err, "cat "+eventsPath))
+	} else {
+		oomKills := int64(events["oom_kill"])
+		c.Trace(c.Name(), eventsPath, fmt.Sprintf("oom %d, oom_kill %d, max %d", events["oom"], events["oom_kill"], events["max"]), float64(oomKills))
+		checks = append(checks, use.Check{
+			Resource:    "Cgroup (memory)",
+			Type:        use.Errors,
+			Value:       fmt.Sprintf("%d", oomKills),
+			RawValue:    float64(oomKills),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Status:      use.EvaluateErrors(oomKills),
+			Description: fmt.Sprintf("OOM kills in this cgroup (memory.max hit %d times)", events["max"]),
+			Command:     "cat " + eventsPath,
+		})
+	}
+
+	return checks, nil
+}
+
+// cgroupDir returns this process's cgroup v2 directory, from the "0::" line
+// of /proc/self/cgroup. ok is false on cgroup v1 or hybrid hierarchies.
+func cgroupDir() (string, bool) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(line, "0::"); ok {
+			return filepath.Join(cgroupRoot, rest), true
+		}
+	}
+	return "", false
+}
+
+// readLimit reads a single-value limit file such as memory.max. ok is false
+// if the file is missing or the value is "max" (unlimited).
+func readLimit(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return v, err == nil && v > 0
+}
+
+// readCPUMax parses cpu.max ("$QUOTA $PERIOD", quota "max" when unlimited).
+func readCPUMax(path string) (quota, period uint64, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	quota, err1 := strconv.ParseUint(fields[0], 10, 64)
+	period, err2 := strconv.ParseUint(fields[1], 10, 64)
+	return quota, period, err1 == nil && err2 == nil && quota > 0 && period > 0
+}
+
+// readUint reads a file containing a single unsigned integer.
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readKeyValues parses flat-keyed files such as cpu.stat and memory.events.
+func readKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = v
+		}
+	}
+	return values, scanner.Err()
+}
+
+// readIOStat sums io.stat counters across devices. Lines look like
+// "8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0".
+func readIOStat(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	totals := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for _, f := range fields[min(1, len(fields)):] {
+			key, val, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			if v, err := strconv.ParseUint(val, 10, 64); err == nil {
+				totals[key] += v
+			}
+		}
+	}
+	return totals, scanner.Err()
+}
+
+// counterDelta returns how far a cumulative counter advanced between two
+// reads, or 0 if it went backwards (the cgroup was recreated).
+func counterDelta(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// unknownCheck reports a cgroup metric that could not be read.
+func unknownCheck(resource string, mtype use.MetricType, err error, command string) use.Check {
+	return use.Check{
+		Resource:    resource,
+		Type:        mtype,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: err.Error(),
+		Command:     command,
+	}
+}
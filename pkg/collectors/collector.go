@@ -1,7 +1,13 @@
 // Package collectors provides interfaces and implementations for system metric collection.
 package collectors
 
-import "github.com/danpilch/umd/pkg/use"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
 
 // Collector is the interface that all resource collectors must implement.
 type Collector interface {
@@ -43,3 +49,69 @@ func (r *Registry) GetByName(name string) Collector {
 	}
 	return nil
 }
+
+// Select returns the registered collectors named in names, matched
+// case-insensitively and kept in registration order, e.g. to run only
+// "cpu", "memory" and "disk" and skip slower collectors. Empty names are
+// ignored. An unknown name is an error listing the valid ones.
+func (r *Registry) Select(names ...string) ([]Collector, error) {
+	want, err := r.match(names)
+	if err != nil {
+		return nil, err
+	}
+	var selected []Collector
+	for _, c := range r.collectors {
+		if want[strings.ToLower(c.Name())] {
+			selected = append(selected, c)
+		}
+	}
+	return selected, nil
+}
+
+// Disable removes the named collectors from the registry, matched
+// case-insensitively, e.g. those listed under "disabled" in the config file.
+// An unknown name is an error listing the valid ones, and nothing is removed.
+func (r *Registry) Disable(names ...string) error {
+	drop, err := r.match(names)
+	if err != nil {
+		return err
+	}
+	kept := make([]Collector, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		if !drop[strings.ToLower(c.Name())] {
+			kept = append(kept, c)
+		}
+	}
+	r.collectors = kept
+	return nil
+}
+
+// match lowercases names into a set, ignoring empty ones, and checks that
+// each names a registered collector.
+func (r *Registry) match(names []string) (map[string]bool, error) {
+	want := make(map[string]bool)
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			want[name] = true
+		}
+	}
+
+	found := make(map[string]bool)
+	valid := make([]string, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		name := strings.ToLower(c.Name())
+		valid = append(valid, name)
+		found[name] = want[name]
+	}
+	var unknown []string
+	for name := range want {
+		if !found[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown collector %s (valid: %s)", strings.Join(unknown, ", "), strings.Join(valid, ", "))
+	}
+	return want, nil
+}
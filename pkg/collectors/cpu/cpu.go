@@ -1,18 +1,59 @@
 // Package cpu provides CPU metrics collection for the USE method.
 package cpu
 
+import (
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// DefaultSampleInterval is the window between the two CPU counter reads when
+// SampleInterval is zero.
+const DefaultSampleInterval = 100 * time.Millisecond
+
+// minSampleInterval keeps the delta math away from near-zero windows.
+const minSampleInterval = 10 * time.Millisecond
+
 // Collector gathers CPU-related USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+
+	// SampleInterval is the window between the two counter reads. Longer
+	// windows smooth noise; shorter ones reduce latency. Zero uses
+	// DefaultSampleInterval; values below 10ms are clamped.
+	SampleInterval time.Duration
+
+	// PerCore adds a utilization check per core ("CPU (core0)", ...)
+	// alongside the aggregate, exposing a single pegged core that the
+	// average hides. Linux only.
+	PerCore bool
+}
 
 // New creates a new CPU collector.
 func New() *Collector {
 	return &Collector{}
 }
 
+// NewWithInterval creates a CPU collector that samples over the given window.
+func NewWithInterval(interval time.Duration) *Collector {
+	return &Collector{SampleInterval: interval}
+}
+
+// interval returns the effective sampling window.
+func (c *Collector) interval() time.Duration {
+	if c.SampleInterval == 0 {
+		return DefaultSampleInterval
+	}
+	if c.SampleInterval < minSampleInterval {
+		return minSampleInterval
+	}
+	return c.SampleInterval
+}
+
 // Name returns the collector name.
 func (c *Collector) Name() string {
 	return "CPU"
 }
 
-// Collect gathers CPU metrics. Platform-specific implementation in cpu_linux.go and cpu_darwin.go.
+// Collect gathers CPU metrics. Platform-specific implementation in cpu_linux.go, cpu_darwin.go, cpu_windows.go and cpu_freebsd.go.
 // The Collect method is implemented in platform-specific files.
@@ -3,7 +3,7 @@
 package cpu
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
@@ -42,6 +42,12 @@ func (t CPUTicks) Busy() uint64 {
 
 // Collect gathers CPU USE metrics on macOS.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but kills the commands it runs once ctx
+// is done.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
 	// Utilization
@@ -61,14 +67,16 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%%", util),
 			RawValue:    util,
-			Status:      thresholds.EvaluateUtilization(util),
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("CPU", util),
 			Description: "CPU busy percentage",
 			Command:     "host_processor_info",
 		})
 	}
 
 	// Saturation (load average)
-	sat, load, err := c.getSaturation()
+	sat, load, err := c.getSaturation(ctx)
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "CPU",
@@ -79,28 +87,27 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Command:     "sysctl vm.loadavg",
 		})
 	} else {
-		status := use.StatusOK
-		if sat > 1.0 {
-			status = use.StatusWarning
-		}
 		checks = append(checks, use.Check{
 			Resource:    "CPU",
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.2f", load),
 			RawValue:    sat,
-			Status:      status,
+			Unit:        use.UnitRatio,
+			Status:      thresholds.EvaluateSaturationFor("CPU", use.Saturation, sat),
 			Description: fmt.Sprintf("Load average (1min) / CPU count (%d)", runtime.NumCPU()),
 			Command:     "sysctl vm.loadavg",
 		})
 	}
 
 	// Errors (from system.log - best effort)
-	errCount := c.getErrors()
+	errCount := c.getErrors(ctx)
 	checks = append(checks, use.Check{
 		Resource:    "CPU",
 		Type:        use.Errors,
 		Value:       fmt.Sprintf("%d", errCount),
 		RawValue:    float64(errCount),
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
 		Status:      use.EvaluateErrors(errCount),
 		Description: "CPU errors from system log",
 		Command:     "log show",
@@ -116,7 +123,7 @@ func (c *Collector) getUtilization() (float64, error) {
 		return 0, err
 	}
 
-	time.Sleep(100 * time.Millisecond)
+	time.Sleep(c.interval())
 
 	ticks2, err := getCPUTicks()
 	if err != nil {
@@ -129,15 +136,18 @@ func (c *Collector) getUtilization() (float64, error) {
 	}
 
 	busyDelta := float64(ticks2.Busy() - ticks1.Busy())
-	return (busyDelta / totalDelta) * 100, nil
+	util := (busyDelta / totalDelta) * 100
+	c.Trace(c.Name(), "host_processor_info", fmt.Sprintf("busy=%d total=%d -> busy=%d total=%d",
+		ticks1.Busy(), ticks1.Total(), ticks2.Busy(), ticks2.Total()), util)
+	return util, nil
 }
 
 // getCPUTicks retrieves CPU tick counts using Mach host_processor_info.
 func getCPUTicks() (CPUTicks, error) {
 	var (
-		numCPU         C.natural_t
-		cpuInfo        *C.integer_t
-		numCPUInfo     C.mach_msg_type_number_t
+		numCPU     C.natural_t
+		cpuInfo    *C.integer_t
+		numCPUInfo C.mach_msg_type_number_t
 	)
 
 	host := C.mach_host_self()
@@ -162,8 +172,8 @@ func getCPUTicks() (CPUTicks, error) {
 }
 
 // getSaturation returns load average relative to CPU count.
-func (c *Collector) getSaturation() (float64, float64, error) {
-	cmd := exec.Command("sysctl", "-n", "vm.loadavg")
+func (c *Collector) getSaturation(ctx context.Context) (float64, float64, error) {
+	cmd := exec.CommandContext(ctx, "sysctl", "-n", "vm.loadavg")
 	out, err := cmd.Output()
 	if err != nil {
 		return 0, 0, err
@@ -181,27 +191,39 @@ func (c *Collector) getSaturation() (float64, float64, error) {
 		return 0, 0, err
 	}
 
+	c.Trace(c.Name(), "sysctl vm.loadavg", strings.TrimSpace(string(out)), load1)
+
 	cpuCount := float64(runtime.NumCPU())
 	return load1 / cpuCount, load1, nil
 }
 
 // getErrors checks for CPU-related errors in system logs.
-func (c *Collector) getErrors() int64 {
+func (c *Collector) getErrors(ctx context.Context) int64 {
 	// Best effort - check system.log for CPU errors
-	cmd := exec.Command("log", "show", "--predicate", "eventMessage contains 'CPU' AND eventMessage contains 'error'", "--last", "1h", "--style", "compact")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	out, err := use.CommandOutput(ctx, "log", "show", "--predicate", "eventMessage contains 'CPU' AND eventMessage contains 'error'", "--last", "1h", "--style", "compact")
 	if err != nil {
 		return 0
 	}
 
-	lines := strings.Split(out.String(), "\n")
+	lines := strings.Split(string(out), "\n")
 	count := int64(0)
 	for _, line := range lines {
 		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Timestamp") {
 			count++
 		}
 	}
+	c.Trace(c.Name(), "log show", fmt.Sprintf("%d CPU error lines", count), float64(count))
 	return count
 }
+
+// Preflight reports whether the unified log can be queried.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.RunAccess("log", "show", "--last", "1s", "--style", "compact")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "log show",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "CPU errors always reported as 0",
+	}}
+}
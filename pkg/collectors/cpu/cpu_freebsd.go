@@ -0,0 +1,182 @@
+//go:build freebsd
+
+package cpu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// CPUTicks holds CPU tick counts in the kern.cp_time order.
+type CPUTicks struct {
+	User   uint64
+	Nice   uint64
+	System uint64
+	Intr   uint64
+	Idle   uint64
+}
+
+// Total returns total ticks.
+func (t CPUTicks) Total() uint64 {
+	return t.User + t.Nice + t.System + t.Intr + t.Idle
+}
+
+// Busy returns busy ticks.
+func (t CPUTicks) Busy() uint64 {
+	return t.User + t.Nice + t.System + t.Intr
+}
+
+// Collect gathers CPU USE metrics on FreeBSD from sysctl. Errors are
+// reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0, 3)
+
+	// Utilization
+	util, err := c.getUtilization()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Utilization,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "sysctl kern.cp_time",
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f%%", util),
+			RawValue:    util,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("CPU", util),
+			Description: "CPU busy percentage",
+			Command:     "sysctl kern.cp_time",
+		})
+	}
+
+	// Saturation (load average)
+	sat, load, err := c.getSaturation()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "sysctl vm.loadavg",
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.2f", load),
+			RawValue:    sat,
+			Unit:        use.UnitRatio,
+			Status:      thresholds.EvaluateSaturationFor("CPU", use.Saturation, sat),
+			Description: fmt.Sprintf("Load average / CPU count (%d)", runtime.NumCPU()),
+			Command:     "sysctl vm.loadavg",
+		})
+	}
+
+	checks = append(checks, use.Check{
+		Resource:    "CPU",
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: "CPU errors are not collected on FreeBSD",
+		Command:     "-",
+	})
+
+	return checks, nil
+}
+
+// getUtilization calculates CPU utilization from two kern.cp_time reads.
+func (c *Collector) getUtilization() (float64, error) {
+	t1, err := getCPUTicks()
+	if err != nil {
+		return 0, err
+	}
+
+	time.Sleep(c.interval())
+
+	t2, err := getCPUTicks()
+	if err != nil {
+		return 0, err
+	}
+
+	totalDelta := float64(t2.Total() - t1.Total())
+	if totalDelta == 0 {
+		return 0, nil
+	}
+
+	busyDelta := float64(t2.Busy() - t1.Busy())
+	util := (busyDelta / totalDelta) * 100
+	c.Trace(c.Name(), "sysctl kern.cp_time", fmt.Sprintf("busy=%d total=%d -> busy=%d total=%d",
+		t1.Busy(), t1.Total(), t2.Busy(), t2.Total()), util)
+	return util, nil
+}
+
+// getCPUTicks reads the system-wide tick counters. kern.cp_time is an array
+// of five C longs, so the element size follows the platform word size.
+func getCPUTicks() (CPUTicks, error) {
+	buf, err := unix.SysctlRaw("kern.cp_time")
+	if err != nil {
+		return CPUTicks{}, fmt.Errorf("sysctl kern.cp_time: %w", err)
+	}
+
+	var ticks [5]uint64
+	switch len(buf) {
+	case 5 * 8:
+		for i := range ticks {
+			ticks[i] = binary.NativeEndian.Uint64(buf[i*8:])
+		}
+	case 5 * 4:
+		for i := range ticks {
+			ticks[i] = uint64(binary.NativeEndian.Uint32(buf[i*4:]))
+		}
+	default:
+		return CPUTicks{}, fmt.Errorf("sysctl kern.cp_time: unexpected size %d", len(buf))
+	}
+
+	return CPUTicks{
+		User:   ticks[0],
+		Nice:   ticks[1],
+		System: ticks[2],
+		Intr:   ticks[3],
+		Idle:   ticks[4],
+	}, nil
+}
+
+// getSaturation returns the 1-minute load average relative to CPU count.
+// vm.loadavg holds fixed-point values scaled by kern.fscale.
+func (c *Collector) getSaturation() (float64, float64, error) {
+	buf, err := unix.SysctlRaw("vm.loadavg")
+	if err != nil {
+		return 0, 0, fmt.Errorf("sysctl vm.loadavg: %w", err)
+	}
+	if len(buf) < 4 {
+		return 0, 0, fmt.Errorf("sysctl vm.loadavg: unexpected size %d", len(buf))
+	}
+	fscale, err := unix.SysctlUint32("kern.fscale")
+	if err != nil {
+		return 0, 0, fmt.Errorf("sysctl kern.fscale: %w", err)
+	}
+	if fscale == 0 {
+		return 0, 0, fmt.Errorf("sysctl kern.fscale: zero scale")
+	}
+
+	raw := binary.NativeEndian.Uint32(buf)
+	load1 := float64(raw) / float64(fscale)
+	c.Trace(c.Name(), "sysctl vm.loadavg", fmt.Sprintf("ldavg[0]=%d fscale=%d", raw, fscale), load1)
+
+	cpuCount := float64(runtime.NumCPU())
+	return load1 / cpuCount, load1, nil
+}
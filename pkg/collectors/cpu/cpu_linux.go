@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -40,15 +41,18 @@ func (s CPUStats) Busy() uint64 {
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
+	// Per-core samples are needed for per-core checks and for isolated CPUs
+	isolated, isolatedRaw := readIsolated()
+
 	// Utilization
-	util, err := c.getUtilization()
-	if err != nil {
+	util, cores, utilErr := c.getUtilization(c.PerCore || len(isolated) > 0)
+	if utilErr != nil {
 		checks = append(checks, use.Check{
 			Resource:    "CPU",
 			Type:        use.Utilization,
 			Value:       "unknown",
 			Status:      use.StatusUnknown,
-			Description: err.Error(),
+			Description: utilErr.Error(),
 			Command:     "/proc/stat",
 		})
 	} else {
@@ -57,10 +61,33 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%%", util),
 			RawValue:    util,
-			Status:      thresholds.EvaluateUtilization(util),
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("CPU", util),
 			Description: "CPU busy percentage",
 			Command:     "/proc/stat",
 		})
+		if c.PerCore {
+			ids := make([]int, 0, len(cores))
+			for id := range cores {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+			for _, id := range ids {
+				resource := fmt.Sprintf("CPU (core%d)", id)
+				checks = append(checks, use.Check{
+					Resource:    resource,
+					Type:        use.Utilization,
+					Value:       fmt.Sprintf("%.1f%%", cores[id]),
+					RawValue:    cores[id],
+					Unit:        use.UnitPercent,
+					ShowsRaw:    true,
+					Status:      thresholds.EvaluateUtilizationFor(resource, cores[id]),
+					Description: "Core busy percentage",
+					Command:     "/proc/stat",
+				})
+			}
+		}
 	}
 
 	// Saturation (load average)
@@ -75,16 +102,13 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Command:     "/proc/loadavg",
 		})
 	} else {
-		status := use.StatusOK
-		if sat > 1.0 {
-			status = use.StatusWarning
-		}
 		checks = append(checks, use.Check{
 			Resource:    "CPU",
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.2f", load),
 			RawValue:    sat,
-			Status:      status,
+			Unit:        use.UnitRatio,
+			Status:      thresholds.EvaluateSaturationFor("CPU", use.Saturation, sat),
 			Description: fmt.Sprintf("Load average (1min) / CPU count (%d)", runtime.NumCPU()),
 			Command:     "/proc/loadavg",
 		})
@@ -107,43 +131,84 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d", errCount),
 			RawValue:    float64(errCount),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
 			Status:      use.EvaluateErrors(errCount),
 			Description: "CPU errors from kernel log",
 			Command:     "dmesg",
 		})
 	}
 
+	// Isolated CPU affinity violations (only when isolcpus is configured)
+	if len(isolated) > 0 {
+		checks = append(checks, c.isolationCheck(thresholds, isolated, isolatedRaw, cores, utilErr))
+	}
+
 	return checks, nil
 }
 
 // getUtilization calculates CPU utilization by sampling /proc/stat twice.
-func (c *Collector) getUtilization() (float64, error) {
-	stats1, err := readCPUStats()
+// When perCore is set it also returns each core's utilization over the same
+// window, keyed by CPU number.
+func (c *Collector) getUtilization(perCore bool) (float64, map[int]float64, error) {
+	stats1, line1, err := readCPUStats()
 	if err != nil {
-		return 0, err
+		return 0, nil, err
+	}
+	var cores1 map[int]CPUStats
+	if perCore {
+		if cores1, err = readPerCPUStats(); err != nil {
+			return 0, nil, err
+		}
 	}
 
-	time.Sleep(100 * time.Millisecond)
+	time.Sleep(c.interval())
 
-	stats2, err := readCPUStats()
+	stats2, line2, err := readCPUStats()
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
-	totalDelta := float64(stats2.Total() - stats1.Total())
-	if totalDelta == 0 {
-		return 0, nil
+	util := busyPercent(stats1, stats2)
+	c.Trace(c.Name(), "/proc/stat", line1+" -> "+line2, util)
+
+	if !perCore {
+		return util, nil, nil
 	}
+	cores2, err := readPerCPUStats()
+	if err != nil {
+		return 0, nil, err
+	}
+	// Cores can go offline between reads; match by CPU number
+	cores := make(map[int]float64, len(cores2))
+	for id, s2 := range cores2 {
+		s1, ok := cores1[id]
+		if !ok {
+			continue
+		}
+		u := busyPercent(s1, s2)
+		c.Trace(c.Name(), "/proc/stat", fmt.Sprintf("cpu%d busy %d -> %d of %d -> %d", id, s1.Busy(), s2.Busy(), s1.Total(), s2.Total()), u)
+		cores[id] = u
+	}
+	return util, cores, nil
+}
 
-	busyDelta := float64(stats2.Busy() - stats1.Busy())
-	return (busyDelta / totalDelta) * 100, nil
+// busyPercent returns the busy share of CPU time between two samples.
+func busyPercent(s1, s2 CPUStats) float64 {
+	totalDelta := float64(s2.Total() - s1.Total())
+	if totalDelta == 0 {
+		return 0
+	}
+	busyDelta := float64(s2.Busy() - s1.Busy())
+	return (busyDelta / totalDelta) * 100
 }
 
-// readCPUStats reads CPU statistics from /proc/stat.
-func readCPUStats() (CPUStats, error) {
+// readCPUStats reads the aggregate "cpu" line from /proc/stat, returning the
+// raw line parsed.
+func readCPUStats() (CPUStats, string, error) {
 	file, err := os.Open("/proc/stat")
 	if err != nil {
-		return CPUStats{}, err
+		return CPUStats{}, "", err
 	}
 	defer file.Close()
 
@@ -153,25 +218,29 @@ func readCPUStats() (CPUStats, error) {
 		if strings.HasPrefix(line, "cpu ") {
 			fields := strings.Fields(line)
 			if len(fields) < 8 {
-				return CPUStats{}, fmt.Errorf("unexpected /proc/stat format")
-			}
-
-			stats := CPUStats{}
-			stats.User, _ = strconv.ParseUint(fields[1], 10, 64)
-			stats.Nice, _ = strconv.ParseUint(fields[2], 10, 64)
-			stats.System, _ = strconv.ParseUint(fields[3], 10, 64)
-			stats.Idle, _ = strconv.ParseUint(fields[4], 10, 64)
-			stats.IOWait, _ = strconv.ParseUint(fields[5], 10, 64)
-			stats.IRQ, _ = strconv.ParseUint(fields[6], 10, 64)
-			stats.SoftIRQ, _ = strconv.ParseUint(fields[7], 10, 64)
-			if len(fields) > 8 {
-				stats.Steal, _ = strconv.ParseUint(fields[8], 10, 64)
+				return CPUStats{}, line, fmt.Errorf("unexpected /proc/stat format")
 			}
-			return stats, nil
+			return parseCPUFields(fields), line, nil
 		}
 	}
 
-	return CPUStats{}, fmt.Errorf("cpu line not found in /proc/stat")
+	return CPUStats{}, "", fmt.Errorf("cpu line not found in /proc/stat")
+}
+
+// parseCPUFields parses the counters of a /proc/stat cpu line.
+func parseCPUFields(fields []string) CPUStats {
+	stats := CPUStats{}
+	stats.User, _ = strconv.ParseUint(fields[1], 10, 64)
+	stats.Nice, _ = strconv.ParseUint(fields[2], 10, 64)
+	stats.System, _ = strconv.ParseUint(fields[3], 10, 64)
+	stats.Idle, _ = strconv.ParseUint(fields[4], 10, 64)
+	stats.IOWait, _ = strconv.ParseUint(fields[5], 10, 64)
+	stats.IRQ, _ = strconv.ParseUint(fields[6], 10, 64)
+	stats.SoftIRQ, _ = strconv.ParseUint(fields[7], 10, 64)
+	if len(fields) > 8 {
+		stats.Steal, _ = strconv.ParseUint(fields[8], 10, 64)
+	}
+	return stats
 }
 
 // getSaturation returns load average relative to CPU count.
@@ -191,6 +260,8 @@ func (c *Collector) getSaturation() (float64, float64, error) {
 		return 0, 0, err
 	}
 
+	c.Trace(c.Name(), "/proc/loadavg", strings.TrimSpace(string(data)), load1)
+
 	cpuCount := float64(runtime.NumCPU())
 	return load1 / cpuCount, load1, nil
 }
@@ -217,5 +288,18 @@ func (c *Collector) getErrors() (int64, error) {
 		}
 	}
 
+	c.Trace(c.Name(), "/var/log/kern.log", fmt.Sprintf("%d MCE/CPU error lines", count), float64(count))
 	return count, nil
 }
+
+// Preflight reports whether the kernel log used for CPU errors is readable.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.ReadAccess("/var/log/kern.log")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "/var/log/kern.log",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "CPU errors (MCE) always reported as 0",
+	}}
+}
@@ -0,0 +1,166 @@
+//go:build windows
+
+package cpu
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+var (
+	modkernel32        = windows.NewLazySystemDLL("kernel32.dll")
+	procGetSystemTimes = modkernel32.NewProc("GetSystemTimes")
+)
+
+// queueLengthCounter counts threads ready to run but waiting for a CPU.
+const queueLengthCounter = `\System\Processor Queue Length`
+
+// CPUTimes holds system-wide CPU time counters in 100ns units. Kernel time
+// includes idle time, as GetSystemTimes reports it.
+type CPUTimes struct {
+	Idle   uint64
+	Kernel uint64
+	User   uint64
+}
+
+// Total returns total time.
+func (t CPUTimes) Total() uint64 {
+	return t.Kernel + t.User
+}
+
+// Busy returns non-idle time.
+func (t CPUTimes) Busy() uint64 {
+	return t.Kernel - t.Idle + t.User
+}
+
+// Collect gathers CPU USE metrics on Windows. Errors have no counterpart
+// to the kernel logs read elsewhere and are reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0, 3)
+
+	// Utilization
+	util, err := c.getUtilization()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Utilization,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "GetSystemTimes",
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f%%", util),
+			RawValue:    util,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("CPU", util),
+			Description: "CPU busy percentage",
+			Command:     "GetSystemTimes",
+		})
+	}
+
+	// Saturation (threads ready to run but waiting for a CPU)
+	sat, queue, err := c.getSaturation()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     queueLengthCounter,
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "CPU",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.0f", queue),
+			RawValue:    sat,
+			Unit:        use.UnitRatio,
+			Status:      thresholds.EvaluateSaturationFor("CPU", use.Saturation, sat),
+			Description: fmt.Sprintf("Processor queue length / CPU count (%d)", runtime.NumCPU()),
+			Command:     queueLengthCounter,
+		})
+	}
+
+	checks = append(checks, use.Check{
+		Resource:    "CPU",
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: "CPU errors are not collected on Windows",
+		Command:     "-",
+	})
+
+	return checks, nil
+}
+
+// getUtilization calculates CPU utilization from two GetSystemTimes reads.
+func (c *Collector) getUtilization() (float64, error) {
+	t1, err := getCPUTimes()
+	if err != nil {
+		return 0, err
+	}
+
+	time.Sleep(c.interval())
+
+	t2, err := getCPUTimes()
+	if err != nil {
+		return 0, err
+	}
+
+	totalDelta := float64(t2.Total() - t1.Total())
+	if totalDelta == 0 {
+		return 0, nil
+	}
+
+	busyDelta := float64(t2.Busy() - t1.Busy())
+	util := (busyDelta / totalDelta) * 100
+	c.Trace(c.Name(), "GetSystemTimes", fmt.Sprintf("busy=%d total=%d -> busy=%d total=%d",
+		t1.Busy(), t1.Total(), t2.Busy(), t2.Total()), util)
+	return util, nil
+}
+
+// getCPUTimes reads system-wide idle, kernel and user time.
+func getCPUTimes() (CPUTimes, error) {
+	var idle, kernel, user windows.Filetime
+	r, _, err := procGetSystemTimes.Call(
+		uintptr(unsafe.Pointer(&idle)),
+		uintptr(unsafe.Pointer(&kernel)),
+		uintptr(unsafe.Pointer(&user)))
+	if r == 0 {
+		return CPUTimes{}, fmt.Errorf("GetSystemTimes failed: %w", err)
+	}
+	return CPUTimes{
+		Idle:   filetimeTicks(idle),
+		Kernel: filetimeTicks(kernel),
+		User:   filetimeTicks(user),
+	}, nil
+}
+
+// filetimeTicks returns a FILETIME duration in 100ns units.
+func filetimeTicks(ft windows.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
+
+// getSaturation returns the processor queue length relative to CPU count.
+// The counter is instantaneous, so a single collection suffices.
+func (c *Collector) getSaturation() (float64, float64, error) {
+	values, err := use.PerfCounters(0, queueLengthCounter)
+	if err != nil {
+		return 0, 0, err
+	}
+	queue := values[queueLengthCounter]
+	c.Trace(c.Name(), queueLengthCounter, fmt.Sprintf("%.0f", queue), queue)
+	return queue / float64(runtime.NumCPU()), queue, nil
+}
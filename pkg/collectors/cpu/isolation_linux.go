@@ -0,0 +1,128 @@
+//go:build linux
+
+package cpu
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// readIsolated returns the isolated CPUs (isolcpus) and the raw list, or
+// nil when none are isolated.
+func readIsolated() ([]int, string) {
+	data, err := os.ReadFile("/sys/devices/system/cpu/isolated")
+	if err != nil {
+		return nil, ""
+	}
+	raw := strings.TrimSpace(string(data))
+	isolated, err := parseCPUList(raw)
+	if err != nil {
+		return nil, ""
+	}
+	return isolated, raw
+}
+
+// isolationCheck flags isolated CPUs showing unexpected utilization, using
+// the per-core utilization from the collector's sample window. Each core is
+// judged against the "CPU (isolated)" errors limit. utilErr is the error from
+// that sample, if any.
+func (c *Collector) isolationCheck(thresholds use.Thresholds, isolated []int, raw string, cores map[int]float64, utilErr error) use.Check {
+	check := use.Check{
+		Resource: "CPU (isolated)",
+		Type:     use.Errors,
+		Command:  "/sys/devices/system/cpu/isolated",
+	}
+	if utilErr != nil {
+		check.Value = "unknown"
+		check.Status = use.StatusUnknown
+		check.Description = utilErr.Error()
+		return check
+	}
+
+	status := use.StatusOK
+	var maxUtil float64
+	var busy []string
+	for _, cpu := range isolated {
+		util, ok := cores[cpu]
+		if !ok {
+			continue
+		}
+		if util > maxUtil {
+			maxUtil = util
+		}
+		if s := thresholds.EvaluateSaturationFor("CPU (isolated)", use.Errors, util); s != use.StatusOK {
+			busy = append(busy, fmt.Sprintf("cpu%d %.0f%%", cpu, util))
+			if s.Worse(status) {
+				status = s
+			}
+		}
+	}
+	c.Trace(c.Name(), "/sys/devices/system/cpu/isolated", raw, maxUtil)
+
+	check.Value = fmt.Sprintf("%d busy", len(busy))
+	check.RawValue = maxUtil
+	check.Unit = use.UnitPercent
+	check.Status = status
+	limits, _ := thresholds.SaturationLimitsFor("CPU (isolated)", use.Errors)
+	check.Description = fmt.Sprintf("Isolated CPUs %s, none above %.0f%%", raw, limits.Warn)
+	if len(busy) > 0 {
+		check.Description = fmt.Sprintf("Isolated CPUs running load (affinity leak?): %s", strings.Join(busy, ", "))
+	}
+	return check
+}
+
+// readPerCPUStats reads per-core statistics from the cpuN lines of /proc/stat.
+func readPerCPUStats() (map[int]CPUStats, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[int]CPUStats)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || !strings.HasPrefix(fields[0], "cpu") || fields[0] == "cpu" {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[0], "cpu"))
+		if err != nil {
+			continue
+		}
+		result[id] = parseCPUFields(fields)
+	}
+	return result, scanner.Err()
+}
+
+// parseCPUList parses a kernel CPU list such as "2-5,8,10-11".
+func parseCPUList(s string) ([]int, error) {
+	var cpus []int
+	if s == "" {
+		return cpus, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu list %q", s)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(hi); err != nil {
+				return nil, fmt.Errorf("invalid cpu list %q", s)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
@@ -3,20 +3,56 @@ package disk
 
 import (
 	"fmt"
-
-	"golang.org/x/sys/unix"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
 
+// DefaultSampleInterval is the window between the two disk counter reads when
+// SampleInterval is zero.
+const DefaultSampleInterval = 100 * time.Millisecond
+
+// minSampleInterval keeps the delta math away from near-zero windows.
+const minSampleInterval = 10 * time.Millisecond
+
 // Collector gathers disk-related USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+
+	// SampleInterval is the window between the two counter reads. Longer
+	// windows smooth noise; shorter ones reduce latency. Zero uses
+	// DefaultSampleInterval; values below 10ms are clamped.
+	SampleInterval time.Duration
+
+	// Iostat takes utilization and average queue size from `iostat -x`
+	// (%util and aqu-sz) instead of /proc/diskstats math. It needs the
+	// sysstat package and samples for at least a second; devices iostat
+	// doesn't report, or a missing iostat, fall back to /proc/diskstats.
+	// Linux only.
+	Iostat bool
+}
 
 // New creates a new disk collector.
 func New() *Collector {
 	return &Collector{}
 }
 
+// NewWithInterval creates a disk collector that samples over the given window.
+func NewWithInterval(interval time.Duration) *Collector {
+	return &Collector{SampleInterval: interval}
+}
+
+// interval returns the effective sampling window.
+func (c *Collector) interval() time.Duration {
+	if c.SampleInterval == 0 {
+		return DefaultSampleInterval
+	}
+	if c.SampleInterval < minSampleInterval {
+		return minSampleInterval
+	}
+	return c.SampleInterval
+}
+
 // Name returns the collector name.
 func (c *Collector) Name() string {
 	return "Disk"
@@ -31,30 +67,11 @@ type Filesystem struct {
 	Available  uint64
 }
 
-// GetFilesystemUsage returns filesystem capacity metrics using statfs.
-// This is cross-platform (works on both Linux and macOS).
-func GetFilesystemUsage(mountPoint string) (*Filesystem, error) {
-	var stat unix.Statfs_t
-	if err := unix.Statfs(mountPoint, &stat); err != nil {
-		return nil, err
-	}
-
-	blockSize := uint64(stat.Bsize)
-	total := stat.Blocks * blockSize
-	available := stat.Bavail * blockSize
-	used := total - (stat.Bfree * blockSize)
-
-	return &Filesystem{
-		MountPoint: mountPoint,
-		Total:      total,
-		Used:       used,
-		Available:  available,
-	}, nil
-}
-
 // GetFilesystemChecks returns USE checks for filesystem capacity.
 func GetFilesystemChecks(thresholds use.Thresholds, mountPoints []string) []use.Check {
 	checks := make([]use.Check, 0)
+	now := time.Now()
+	projector := newFillProjector(now)
 
 	for _, mp := range mountPoints {
 		fs, err := GetFilesystemUsage(mp)
@@ -67,15 +84,27 @@ func GetFilesystemChecks(thresholds use.Thresholds, mountPoints []string) []use.
 		}
 
 		utilPercent := (float64(fs.Used) / float64(fs.Total)) * 100
-		checks = append(checks, use.Check{
+		check := use.Check{
 			Resource:    fmt.Sprintf("Filesystem (%s)", mp),
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%%", utilPercent),
 			RawValue:    utilPercent,
-			Status:      thresholds.EvaluateUtilization(utilPercent),
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor(fmt.Sprintf("Filesystem (%s)", mp), utilPercent),
 			Description: fmt.Sprintf("Used: %s / Total: %s", formatBytes(fs.Used), formatBytes(fs.Total)),
-			Command:     "statfs",
-		})
+			Command:     statfsCommand,
+		}
+
+		// Time-to-full from the fill trend since the last run; a slow fill
+		// to 100% warns before it crosses the capacity threshold
+		if ttf, ok := projector.project(check, now); ok {
+			check.Description += fmt.Sprintf("; full in ~%s at current rate", formatTimeToFull(ttf))
+			if ttf <= fillWarnHorizon && check.Status == use.StatusOK {
+				check.Status = use.StatusWarning
+			}
+		}
+		checks = append(checks, check)
 	}
 
 	return checks
@@ -5,29 +5,40 @@ package disk
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
 
 // Collect gathers disk USE metrics on macOS.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but kills the commands it runs once ctx
+// is done.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0)
 
 	// Get disk I/O stats from iostat
-	ioStats, err := getIOStats()
+	ioStats, err := getIOStats(ctx, c.interval())
 	if err == nil {
 		for disk, stats := range ioStats {
 			// Utilization (KB/sec - can't get % easily on macOS)
 			totalKBs := stats["KB/t"] * (stats["tps"]) // KB/transfer * transfers/sec
+			c.Trace(c.Name(), "iostat", fmt.Sprintf("%s KB/t=%.2f tps=%.0f MB/s=%.2f", disk, stats["KB/t"], stats["tps"], stats["MB/s"]), totalKBs*1024)
 			checks = append(checks, use.Check{
 				Resource:    fmt.Sprintf("Disk (%s)", disk),
 				Type:        use.Utilization,
 				Value:       fmt.Sprintf("%.1f KB/s", totalKBs),
-				RawValue:    totalKBs,
+				RawValue:    totalKBs * 1024,
+				Unit:        use.UnitBytesPerSecond,
+				ShowsRaw:    true,
 				Status:      use.StatusOK, // Can't determine % without max throughput
 				Description: "I/O throughput",
 				Command:     "iostat",
@@ -36,27 +47,30 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			// Saturation - use transfers per second as a proxy
 			// High tps with low KB/t might indicate many small random IOs
 			tps := stats["tps"]
-			satStatus := use.StatusOK
-			if tps > 1000 { // Arbitrary high threshold
-				satStatus = use.StatusWarning
-			}
+			satStatus := thresholds.EvaluateSaturationFor(fmt.Sprintf("Disk (%s)", disk), use.Saturation, tps)
 			checks = append(checks, use.Check{
 				Resource:    fmt.Sprintf("Disk (%s)", disk),
 				Type:        use.Saturation,
 				Value:       fmt.Sprintf("%.1f tps", tps),
 				RawValue:    tps,
+				Unit:        use.UnitPerSecond,
+				ShowsRaw:    true,
+				Label:       "tps",
 				Status:      satStatus,
 				Description: "Transfers per second",
 				Command:     "iostat",
 			})
 
 			// Errors (limited on macOS - check system.log)
-			errCount := getDiskErrors()
+			errCount := getDiskErrors(ctx)
+			c.Trace(c.Name(), "log show", fmt.Sprintf("%d IOStorageFamily error lines", errCount), float64(errCount))
 			checks = append(checks, use.Check{
 				Resource:    fmt.Sprintf("Disk (%s)", disk),
 				Type:        use.Errors,
 				Value:       fmt.Sprintf("%d", errCount),
 				RawValue:    float64(errCount),
+				Unit:        use.UnitCount,
+				ShowsRaw:    true,
 				Status:      use.EvaluateErrors(errCount),
 				Description: "Disk errors from system log",
 				Command:     "log show",
@@ -65,7 +79,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	}
 
 	// Add filesystem capacity checks
-	mountPoints := getMainMountPoints()
+	mountPoints := getMainMountPoints(ctx)
 	checks = append(checks, GetFilesystemChecks(thresholds, mountPoints)...)
 
 	return checks, nil
@@ -73,13 +87,20 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 
 // getIOStats parses iostat output for disk statistics.
 // iostat -d -c 2 outputs:
-//               disk0
-//     KB/t  tps  MB/s
-//    24.44  232  5.53   <- first sample (cumulative since boot)
-//    12.19   21  0.25   <- second sample (current activity)
-func getIOStats() (map[string]map[string]float64, error) {
-	cmd := exec.Command("iostat", "-d", "-c", "2")
-	out, err := cmd.Output()
+//
+//	           disk0
+//	 KB/t  tps  MB/s
+//	24.44  232  5.53   <- first sample (cumulative since boot)
+//	12.19   21  0.25   <- second sample (current activity)
+//
+// iostat waits whole seconds between samples (1s by default), so wait only
+// lengthens the window when it is above a second.
+func getIOStats(ctx context.Context, wait time.Duration) (map[string]map[string]float64, error) {
+	args := []string{"-d", "-c", "2"}
+	if secs := int(wait.Round(time.Second).Seconds()); secs > 1 {
+		args = append(args, "-w", strconv.Itoa(secs))
+	}
+	out, err := use.CommandOutput(ctx, "iostat", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -130,16 +151,13 @@ func getIOStats() (map[string]map[string]float64, error) {
 }
 
 // getDiskErrors checks for disk-related errors in system logs.
-func getDiskErrors() int64 {
-	cmd := exec.Command("log", "show", "--predicate", "(subsystem == 'com.apple.iokit.IOStorageFamily') AND (eventMessage contains 'error')", "--last", "1h", "--style", "compact")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+func getDiskErrors(ctx context.Context) int64 {
+	out, err := use.CommandOutput(ctx, "log", "show", "--predicate", "(subsystem == 'com.apple.iokit.IOStorageFamily') AND (eventMessage contains 'error')", "--last", "1h", "--style", "compact")
 	if err != nil {
 		return 0
 	}
 
-	lines := strings.Split(out.String(), "\n")
+	lines := strings.Split(string(out), "\n")
 	count := int64(0)
 	for _, line := range lines {
 		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Timestamp") {
@@ -150,12 +168,12 @@ func getDiskErrors() int64 {
 }
 
 // getMainMountPoints returns the main mount points to check.
-func getMainMountPoints() []string {
+func getMainMountPoints(ctx context.Context) []string {
 	// Always check root
 	points := []string{"/"}
 
 	// Get mount points from df
-	cmd := exec.Command("df", "-P")
+	cmd := exec.CommandContext(ctx, "df", "-P")
 	out, err := cmd.Output()
 	if err != nil {
 		return points
@@ -199,3 +217,15 @@ func getMainMountPoints() []string {
 
 	return points
 }
+
+// Preflight reports whether the unified log can be queried.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.RunAccess("log", "show", "--last", "1s", "--style", "compact")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "log show",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "Disk I/O errors always reported as 0",
+	}}
+}
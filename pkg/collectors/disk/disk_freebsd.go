@@ -0,0 +1,75 @@
+//go:build freebsd
+
+package disk
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// virtualFilesystems are mounted filesystem types with no backing capacity.
+var virtualFilesystems = map[string]bool{
+	"devfs":     true,
+	"fdescfs":   true,
+	"procfs":    true,
+	"linprocfs": true,
+	"linsysfs":  true,
+	"tmpfs":     true,
+	"nullfs":    true,
+}
+
+// Collect gathers disk USE metrics on FreeBSD. Capacity of each local
+// filesystem comes from statfs; per-device busy time, queue length and
+// errors are not collected yet and are reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0)
+	const resource = "Disk (total)"
+
+	for _, t := range []use.MetricType{use.Utilization, use.Saturation, use.Errors} {
+		checks = append(checks, use.Check{
+			Resource:    resource,
+			Type:        t,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: "Disk I/O is not collected on FreeBSD",
+			Command:     "-",
+		})
+	}
+
+	checks = append(checks, GetFilesystemChecks(thresholds, getMainMountPoints())...)
+	return checks, nil
+}
+
+// getMainMountPoints returns local, non-virtual mount points from
+// getfsstat, always including the root.
+func getMainMountPoints() []string {
+	points := []string{"/"}
+
+	n, err := unix.Getfsstat(nil, unix.MNT_NOWAIT)
+	if err != nil || n == 0 {
+		return points
+	}
+	buf := make([]unix.Statfs_t, n)
+	n, err = unix.Getfsstat(buf, unix.MNT_NOWAIT)
+	if err != nil {
+		return points
+	}
+
+	seen := map[string]bool{"/": true}
+	for _, fs := range buf[:n] {
+		if fs.Flags&unix.MNT_LOCAL == 0 {
+			continue
+		}
+		if virtualFilesystems[unix.ByteSliceToString(fs.Fstypename[:])] {
+			continue
+		}
+		mp := unix.ByteSliceToString(fs.Mntonname[:])
+		if mp == "" || seen[mp] {
+			continue
+		}
+		seen[mp] = true
+		points = append(points, mp)
+	}
+	return points
+}
@@ -27,23 +27,76 @@ type DiskStats struct {
 	IOsInProgress   uint64
 	TimeDoingIO     uint64
 	WeightedTime    uint64
+	Raw             string // source line, for tracing
+}
+
+// inflightSamples is the number of in-flight I/O readings taken across the
+// sampling window; the last one doubles as the closing diskstats snapshot.
+const inflightSamples = 5
+
+// inflightWindow tracks in-flight I/O depth readings for one device.
+type inflightWindow struct {
+	sum, min, max uint64
+	n             int
+}
+
+func (w *inflightWindow) add(v uint64) {
+	if w.n == 0 || v < w.min {
+		w.min = v
+	}
+	if v > w.max {
+		w.max = v
+	}
+	w.sum += v
+	w.n++
+}
+
+func (w *inflightWindow) avg() float64 {
+	if w.n == 0 {
+		return 0
+	}
+	return float64(w.sum) / float64(w.n)
 }
 
 // Collect gathers disk USE metrics on Linux.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0)
 
+	var iostatRows <-chan map[string]IostatRow
+	if c.Iostat {
+		iostatRows = c.startIostat()
+	}
+
 	// Get disk I/O stats
 	stats1, err := readDiskStats()
 	if err != nil {
 		return nil, err
 	}
 
-	time.Sleep(100 * time.Millisecond)
+	// Sample in-flight I/Os across the window; the final read is stats2
+	start := time.Now()
+	inflight := make(map[string]*inflightWindow, len(stats1))
+	var stats2 map[string]DiskStats
+	for i := 0; i < inflightSamples; i++ {
+		time.Sleep(c.interval() / inflightSamples)
+		stats2, err = readDiskStats()
+		if err != nil {
+			return nil, err
+		}
+		for name, s := range stats2 {
+			if inflight[name] == nil {
+				inflight[name] = &inflightWindow{}
+			}
+			inflight[name].add(s.IOsInProgress)
+		}
+	}
+	// Measure the real window rather than trusting the sleep: timer slack and
+	// scheduling delays make it longer than requested, at sub-ms precision.
+	windowMs := float64(time.Since(start)) / float64(time.Millisecond)
 
-	stats2, err := readDiskStats()
-	if err != nil {
-		return nil, err
+	var iostat map[string]IostatRow
+	if iostatRows != nil {
+		iostat = <-iostatRows
 	}
 
 	for name, s1 := range stats1 {
@@ -57,46 +110,75 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			continue
 		}
 
-		// Utilization (% time doing I/O)
-		timeDelta := float64(s2.TimeDoingIO - s1.TimeDoingIO)
-		// 100ms = 100000 microseconds, TimeDoingIO is in milliseconds
-		utilPercent := timeDelta / 100.0 // Convert to percentage
+		// Utilization (% time doing I/O); TimeDoingIO is in milliseconds
+		utilPercent := diskUtilPercent(s1.TimeDoingIO, s2.TimeDoingIO, windowMs)
+		utilSource := "/proc/diskstats"
+		row, fromIostat := iostat[name]
+		if fromIostat {
+			utilPercent, utilSource = row.Util, "iostat -x"
+			c.Trace(c.Name(), utilSource, row.Raw, utilPercent)
+		} else {
+			c.Trace(c.Name(), utilSource, s1.Raw+" -> "+s2.Raw, utilPercent)
+		}
 
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Disk (%s)", name),
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%%", utilPercent),
 			RawValue:    utilPercent,
-			Status:      thresholds.EvaluateUtilization(utilPercent),
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor(fmt.Sprintf("Disk (%s)", name), utilPercent),
 			Description: "I/O busy percentage",
-			Command:     "/proc/diskstats",
+			Command:     utilSource,
 		})
 
-		// Saturation (average queue size)
+		// Saturation (average queue size). weighted_io_ms grows by the number
+		// of requests in flight for every millisecond, so its delta over the
+		// measured window is the mean queue depth. windowMs is the elapsed
+		// time between the two reads, not the nominal interval, so the value
+		// doesn't drift with sleep jitter or a non-default sample interval.
+		// The limit is the "disk" saturation entry in Thresholds, which can
+		// be raised per device for multi-queue NVMe.
 		weightedDelta := float64(s2.WeightedTime - s1.WeightedTime)
-		avgQueue := weightedDelta / 100.0 // Normalize to seconds
-
-		satStatus := use.StatusOK
-		if avgQueue > 1.0 {
-			satStatus = use.StatusWarning
+		avgQueue := weightedDelta / windowMs
+		queueSource := "/proc/diskstats"
+		if fromIostat && row.HasQueue {
+			avgQueue, queueSource = row.Queue, "iostat -x"
+			c.Trace(c.Name(), queueSource, row.Raw, avgQueue)
+		} else {
+			c.Trace(c.Name(), queueSource, fmt.Sprintf("%s weighted_io_ms: %d -> %d", name, s1.WeightedTime, s2.WeightedTime), avgQueue)
 		}
+
+		satStatus := thresholds.EvaluateSaturationFor(fmt.Sprintf("Disk (%s)", name), use.Saturation, avgQueue)
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Disk (%s)", name),
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.2f avgqu", avgQueue),
 			RawValue:    avgQueue,
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Label:       "avgqu",
 			Status:      satStatus,
 			Description: "Average queue size",
-			Command:     "/proc/diskstats",
+			Command:     queueSource,
 		})
 
+		// Saturation (in-flight I/O depth vs device queue depth)
+		if w := inflight[name]; w != nil {
+			checks = append(checks, c.inflightCheck(name, w))
+		}
+
 		// Errors (from /sys)
 		errCount := getIOErrors(name)
+		c.Trace(c.Name(), fmt.Sprintf("/sys/block/%s/device/ioerr_cnt", name), fmt.Sprintf("%d", errCount), float64(errCount))
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Disk (%s)", name),
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d", errCount),
 			RawValue:    float64(errCount),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
 			Status:      use.EvaluateErrors(errCount),
 			Description: "I/O errors",
 			Command:     "/sys/block/*/device/ioerr_cnt",
@@ -128,7 +210,7 @@ func readDiskStats() (map[string]DiskStats, error) {
 		}
 
 		name := fields[2]
-		s := DiskStats{Name: name}
+		s := DiskStats{Name: name, Raw: scanner.Text()}
 		s.ReadsCompleted, _ = strconv.ParseUint(fields[3], 10, 64)
 		s.ReadsMerged, _ = strconv.ParseUint(fields[4], 10, 64)
 		s.SectorsRead, _ = strconv.ParseUint(fields[5], 10, 64)
@@ -147,6 +229,61 @@ func readDiskStats() (map[string]DiskStats, error) {
 	return stats, scanner.Err()
 }
 
+// inflightCheck reports the average in-flight I/O depth over the window. It
+// warns only when every sample exceeded the device queue depth, i.e. requests
+// are consistently backing up in the block layer rather than briefly bursting.
+func (c *Collector) inflightCheck(name string, w *inflightWindow) use.Check {
+	avg := w.avg()
+	c.Trace(c.Name(), "/proc/diskstats", fmt.Sprintf("%s ios_in_progress: min=%d max=%d n=%d", name, w.min, w.max, w.n), avg)
+
+	check := use.Check{
+		Resource: fmt.Sprintf("Disk (%s)", name),
+		Type:     use.Saturation,
+		Kind:     "inflight",
+		Value:    fmt.Sprintf("%.1f inflight", avg),
+		RawValue: avg,
+		Unit:     use.UnitCount,
+		ShowsRaw: true,
+		Label:    "inflight",
+		Status:   use.StatusOK,
+		Command:  "/proc/diskstats",
+	}
+
+	depth, source := getQueueDepth(name)
+	if depth == 0 {
+		check.Description = fmt.Sprintf("Average in-flight I/Os (max %d); queue depth unknown", w.max)
+		return check
+	}
+	c.Trace(c.Name(), source, fmt.Sprintf("%d", depth), float64(depth))
+
+	check.Value = fmt.Sprintf("%.1f/%d inflight", avg, depth)
+	check.ShowsRaw = false
+	check.Description = fmt.Sprintf("Average in-flight I/Os vs queue depth %d (max %d)", depth, w.max)
+	if w.min > depth {
+		check.Status = use.StatusWarning
+		check.Description = fmt.Sprintf("In-flight I/Os consistently above queue depth %d (min %d, max %d)", depth, w.min, w.max)
+	}
+	return check
+}
+
+// getQueueDepth returns the device's hardware queue depth, falling back to the
+// block layer's nr_requests, along with the path it was read from.
+func getQueueDepth(diskName string) (uint64, string) {
+	for _, path := range []string{
+		fmt.Sprintf("/sys/block/%s/device/queue_depth", diskName),
+		fmt.Sprintf("/sys/block/%s/queue/nr_requests", diskName),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if depth, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil && depth > 0 {
+			return depth, path
+		}
+	}
+	return 0, ""
+}
+
 // isPartition returns true if the disk name appears to be a partition.
 func isPartition(name string) bool {
 	// Check for partition number suffix
@@ -237,3 +374,15 @@ func getMainMountPoints() []string {
 
 	return points
 }
+
+// diskUtilPercent converts an io_ticks delta (ms spent doing I/O) over a
+// window of windowMs into a busy percentage. The kernel accounts io_ticks in
+// jiffies, so on a saturated device the delta can slightly exceed the wall
+// clock window; the result is clamped to [0,100], and a counter reset (e.g.
+// device re-attached) reads as 0 rather than wrapping.
+func diskUtilPercent(ticks1, ticks2 uint64, windowMs float64) float64 {
+	if windowMs <= 0 || ticks2 < ticks1 {
+		return 0
+	}
+	return min(float64(ticks2-ticks1)/windowMs*100, 100)
+}
@@ -0,0 +1,109 @@
+//go:build windows
+
+package disk
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Physical disk counters for all disks combined. % Idle Time is a rate
+// counter and needs two samples; the queue length is instantaneous.
+const (
+	idleTimeCounter    = `\PhysicalDisk(_Total)\% Idle Time`
+	queueLengthCounter = `\PhysicalDisk(_Total)\Current Disk Queue Length`
+)
+
+// Collect gathers disk USE metrics on Windows: busy time and queue length
+// across all physical disks from performance counters, and capacity of each
+// fixed volume. Disk errors are reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0)
+	const resource = "Disk (total)"
+
+	values, err := use.PerfCounters(c.interval(), idleTimeCounter, queueLengthCounter)
+	if err != nil {
+		for _, t := range []use.MetricType{use.Utilization, use.Saturation} {
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        t,
+				Value:       "unknown",
+				Status:      use.StatusUnknown,
+				Description: err.Error(),
+				Command:     "PDH PhysicalDisk",
+			})
+		}
+	} else {
+		// % Idle Time can exceed 100 briefly on multi-disk totals
+		util := min(max(100-values[idleTimeCounter], 0), 100)
+		c.Trace(c.Name(), idleTimeCounter, fmt.Sprintf("%.1f", values[idleTimeCounter]), util)
+		checks = append(checks, use.Check{
+			Resource:    resource,
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f%%", util),
+			RawValue:    util,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor(resource, util),
+			Description: "Time any disk was busy (100 - % Idle Time)",
+			Command:     idleTimeCounter,
+		})
+
+		queue := values[queueLengthCounter]
+		c.Trace(c.Name(), queueLengthCounter, fmt.Sprintf("%.0f", queue), queue)
+		checks = append(checks, use.Check{
+			Resource:    resource,
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.0f", queue),
+			RawValue:    queue,
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateSaturationFor(resource, use.Saturation, queue),
+			Description: "Outstanding I/O requests",
+			Command:     queueLengthCounter,
+		})
+	}
+
+	checks = append(checks, use.Check{
+		Resource:    resource,
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: "Disk errors are not collected on Windows",
+		Command:     "-",
+	})
+
+	checks = append(checks, GetFilesystemChecks(thresholds, getMainMountPoints())...)
+	return checks, nil
+}
+
+// getMainMountPoints returns the root of each fixed volume, e.g. `C:\`.
+// Removable, network and optical drives are skipped so an empty card reader
+// or a slow share doesn't stall or skew the run.
+func getMainMountPoints() []string {
+	buf := make([]uint16, 254)
+	n, err := windows.GetLogicalDriveStrings(uint32(len(buf)), &buf[0])
+	if err != nil || n == 0 || int(n) > len(buf) {
+		return []string{`C:\`}
+	}
+
+	// The buffer holds NUL-terminated roots followed by an empty one
+	var roots []string
+	start := 0
+	for i := 0; i < int(n); i++ {
+		if buf[i] != 0 {
+			continue
+		}
+		if i > start {
+			root := windows.UTF16ToString(buf[start:i])
+			if windows.GetDriveType(&buf[start]) == windows.DRIVE_FIXED {
+				roots = append(roots, root)
+			}
+		}
+		start = i + 1
+	}
+	return roots
+}
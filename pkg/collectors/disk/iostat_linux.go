@@ -0,0 +1,104 @@
+//go:build linux
+
+package disk
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IostatRow is one device's line from the last `iostat -x` report.
+type IostatRow struct {
+	Util     float64 // %util
+	Queue    float64 // aqu-sz (avgqu-sz before sysstat 12)
+	HasQueue bool
+	Raw      string // source line, for tracing
+}
+
+// startIostat runs `iostat -dx` in the background so its whole-second report
+// overlaps the diskstats sampling instead of following it. The channel yields
+// the parsed rows, or nil if iostat is missing or fails.
+func (c *Collector) startIostat() <-chan map[string]IostatRow {
+	rows := make(chan map[string]IostatRow, 1)
+	go func() {
+		r, err := RunIostat(c.interval())
+		if err != nil {
+			c.Trace(c.Name(), "iostat -x", "unavailable, using /proc/diskstats: "+err.Error(), 0)
+		}
+		rows <- r
+	}()
+	return rows
+}
+
+// RunIostat reports extended per-device stats over window, rounded to whole
+// seconds (at least one). The first iostat report covers the time since
+// boot, so two are requested and only the second is kept. The crosscheck
+// package uses it too, so both read iostat the same way.
+func RunIostat(window time.Duration) (map[string]IostatRow, error) {
+	if _, err := exec.LookPath("iostat"); err != nil {
+		return nil, fmt.Errorf("iostat not installed (sysstat package)")
+	}
+	secs := max(int(window.Round(time.Second).Seconds()), 1)
+	cmd := exec.Command("iostat", "-dx", strconv.Itoa(secs), "2")
+	cmd.Env = append(os.Environ(), "LC_ALL=C") // decimal points, not commas
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	rows := parseIostatX(out)
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no %%util column in iostat output")
+	}
+	return rows, nil
+}
+
+// parseIostatX extracts %util and the average queue size per device from the
+// last report of `iostat -dx`, locating columns from the header since their
+// order and names vary across sysstat versions.
+func parseIostatX(out []byte) map[string]IostatRow {
+	rows := make(map[string]IostatRow)
+	utilCol, queueCol := -1, -1
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "Device") {
+			// A new report: keep only the latest
+			clear(rows)
+			utilCol, queueCol = -1, -1
+			for i, f := range fields {
+				switch f {
+				case "%util":
+					utilCol = i
+				case "aqu-sz", "avgqu-sz":
+					queueCol = i
+				}
+			}
+			continue
+		}
+		if utilCol < 0 || utilCol >= len(fields) {
+			continue
+		}
+		util, err := strconv.ParseFloat(fields[utilCol], 64)
+		if err != nil {
+			continue
+		}
+		row := IostatRow{Util: min(util, 100), Raw: strings.TrimSpace(line)}
+		if queueCol >= 0 && queueCol < len(fields) {
+			if q, err := strconv.ParseFloat(fields[queueCol], 64); err == nil {
+				row.Queue, row.HasQueue = q, true
+			}
+		}
+		rows[fields[0]] = row
+	}
+	return rows
+}
@@ -0,0 +1,81 @@
+package disk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/cache"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const (
+	// minProjectionInterval is the shortest gap between runs that gives a
+	// usable fill trend; closer samples are dominated by noise.
+	minProjectionInterval = 5 * time.Minute
+	// maxProjectionAge discards last runs too old to describe the current trend.
+	maxProjectionAge = 7 * 24 * time.Hour
+	// fillWarnHorizon escalates a filesystem projected to fill within this time.
+	fillWarnHorizon = 24 * time.Hour
+)
+
+// fillProjector estimates time-to-full from the previous run's capacity
+// checks in the last-run cache.
+type fillProjector struct {
+	prev map[string]float64
+	at   time.Time
+}
+
+// newFillProjector loads the last run. With no usable run, projections are
+// simply unavailable.
+func newFillProjector(now time.Time) fillProjector {
+	run, err := cache.Load(cache.DefaultPath(), maxProjectionAge)
+	if err != nil {
+		return fillProjector{}
+	}
+	if now.Sub(run.Timestamp) < minProjectionInterval {
+		return fillProjector{}
+	}
+	prev := make(map[string]float64)
+	for _, c := range run.Checks {
+		if isCapacityCheck(c) {
+			prev[c.Key()] = c.RawValue
+		}
+	}
+	return fillProjector{prev: prev, at: run.Timestamp}
+}
+
+// isCapacityCheck reports whether c is a capacity check from
+// GetFilesystemChecks. The filesystem collector's inode usage shares its
+// resource and type, and must not be read as a capacity sample; caches
+// written before Check.Kind existed only tell them apart by description.
+func isCapacityCheck(c use.Check) bool {
+	return c.Type == use.Utilization && c.Kind == "" && strings.HasPrefix(c.Description, "Used: ")
+}
+
+// project returns the estimated time until the capacity check c reaches
+// 100% at the fill rate since the last run. ok is false when there's no
+// growth trend.
+func (p fillProjector) project(c use.Check, now time.Time) (time.Duration, bool) {
+	pct := c.RawValue
+	before, found := p.prev[c.Key()]
+	if !found || pct <= before {
+		return 0, false
+	}
+	perSecond := (pct - before) / now.Sub(p.at).Seconds()
+	return time.Duration((100 - pct) / perSecond * float64(time.Second)), true
+}
+
+// formatTimeToFull renders a projection coarsely, e.g. "3d4h" or "45m".
+func formatTimeToFull(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", hours, int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
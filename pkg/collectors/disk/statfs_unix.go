@@ -0,0 +1,29 @@
+//go:build linux || darwin || freebsd
+
+package disk
+
+import "golang.org/x/sys/unix"
+
+// statfsCommand names the capacity source in filesystem checks.
+const statfsCommand = "statfs"
+
+// GetFilesystemUsage returns filesystem capacity metrics using statfs.
+// This works on Linux, macOS and FreeBSD.
+func GetFilesystemUsage(mountPoint string) (*Filesystem, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(mountPoint, &stat); err != nil {
+		return nil, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	total := stat.Blocks * blockSize
+	available := uint64(stat.Bavail) * blockSize
+	used := total - (stat.Bfree * blockSize)
+
+	return &Filesystem{
+		MountPoint: mountPoint,
+		Total:      total,
+		Used:       used,
+		Available:  available,
+	}, nil
+}
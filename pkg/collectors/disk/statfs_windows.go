@@ -0,0 +1,29 @@
+//go:build windows
+
+package disk
+
+import "golang.org/x/sys/windows"
+
+// statfsCommand names the capacity source in filesystem checks.
+const statfsCommand = "GetDiskFreeSpaceEx"
+
+// GetFilesystemUsage returns volume capacity metrics using
+// GetDiskFreeSpaceEx. Available honors per-user quotas, like statfs's
+// f_bavail.
+func GetFilesystemUsage(mountPoint string) (*Filesystem, error) {
+	path, err := windows.UTF16PtrFromString(mountPoint)
+	if err != nil {
+		return nil, err
+	}
+	var available, total, free uint64
+	if err := windows.GetDiskFreeSpaceEx(path, &available, &total, &free); err != nil {
+		return nil, err
+	}
+
+	return &Filesystem{
+		MountPoint: mountPoint,
+		Total:      total,
+		Used:       total - free,
+		Available:  available,
+	}, nil
+}
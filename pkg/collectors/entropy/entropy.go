@@ -0,0 +1,38 @@
+// Package entropy provides Linux kernel entropy pool metrics for the USE
+// method.
+package entropy
+
+import "github.com/danpilch/umd/pkg/use"
+
+// DefaultLowWatermark is the available entropy, in bits, below which the
+// pool is reported as a warning when LowWatermark is zero.
+const DefaultLowWatermark = 200
+
+// Collector reports the kernel entropy pool fill level. On kernels before
+// 5.18, reads from /dev/random (and early getrandom calls) block while the
+// pool is low, which stalls TLS handshakes on freshly booted VMs.
+type Collector struct {
+	use.TraceHook
+
+	// LowWatermark warns when fewer bits than this are available. Zero uses
+	// DefaultLowWatermark.
+	LowWatermark int
+}
+
+// New creates a new entropy collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "Entropy"
+}
+
+// lowWatermark returns the effective warning level in bits.
+func (c *Collector) lowWatermark() int {
+	if c.LowWatermark <= 0 {
+		return DefaultLowWatermark
+	}
+	return c.LowWatermark
+}
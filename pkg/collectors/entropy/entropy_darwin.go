@@ -0,0 +1,11 @@
+//go:build darwin
+
+package entropy
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, whose Fortuna-based /dev/random never
+// blocks and exposes no pool level.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
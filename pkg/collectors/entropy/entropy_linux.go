@@ -0,0 +1,60 @@
+//go:build linux
+
+package entropy
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const (
+	availPath    = "/proc/sys/kernel/random/entropy_avail"
+	poolsizePath = "/proc/sys/kernel/random/poolsize"
+)
+
+// Collect reports available entropy as a percentage of the pool size on
+// Linux. Unlike other utilization checks a low value is the problem, so the
+// status comes from the low watermark rather than the utilization thresholds.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	avail, err := readInt(availPath)
+	if err != nil {
+		return nil, err
+	}
+	poolsize, err := readInt(poolsizePath)
+	if err != nil {
+		return nil, err
+	}
+	if poolsize <= 0 {
+		return nil, fmt.Errorf("unexpected entropy pool size %d", poolsize)
+	}
+
+	pct := float64(avail) / float64(poolsize) * 100
+	c.Trace(c.Name(), availPath, fmt.Sprintf("entropy_avail=%d poolsize=%d", avail, poolsize), pct)
+
+	status := use.StatusOK
+	if avail < c.lowWatermark() {
+		status = use.StatusWarning
+	}
+	return []use.Check{{
+		Resource:    "Entropy",
+		Type:        use.Utilization,
+		Value:       fmt.Sprintf("%d/%d bits", avail, poolsize),
+		RawValue:    pct,
+		Unit:        use.UnitPercent,
+		Status:      status,
+		Description: fmt.Sprintf("Entropy pool available (warns below %d bits)", c.lowWatermark()),
+		Command:     "cat " + availPath,
+	}}, nil
+}
+
+func readInt(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,126 @@
+// Package exec provides a plugin collector that runs an external command
+// emitting USE checks as JSON.
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// DefaultTimeout bounds how long an external collector may run.
+const DefaultTimeout = 10 * time.Second
+
+// Collector runs an external command whose stdout is a JSON array of checks
+// (the same shape as `umd -f json` checks).
+type Collector struct {
+	use.TraceHook
+
+	name    string
+	Command []string
+
+	// Timeout bounds each run. Zero uses DefaultTimeout; a negative value
+	// disables the deadline, leaving only ctx.
+	Timeout time.Duration
+}
+
+// New creates an exec collector with the given name and command line.
+func New(name string, command []string) *Collector {
+	return &Collector{
+		name:    name,
+		Command: command,
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return c.name
+}
+
+// Collect runs the command and decodes its checks. Failures are reported as
+// a single unknown check rather than an error so other collectors still run.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but also kills the command once ctx is
+// done, whichever comes first of ctx and Timeout.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
+	cmdline := strings.Join(c.Command, " ")
+	if len(c.Command) == 0 {
+		return []use.Check{c.unknown(cmdline, "no command configured")}, nil
+	}
+
+	timeout := c.timeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := osexec.CommandContext(ctx, c.Command[0], c.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded && timeout > 0 {
+			return []use.Check{c.unknown(cmdline, fmt.Sprintf("timed out after %s", timeout))}, nil
+		}
+		if ctx.Err() != nil {
+			return []use.Check{c.unknown(cmdline, fmt.Sprintf("cancelled: %v", ctx.Err()))}, nil
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return []use.Check{c.unknown(cmdline, msg)}, nil
+	}
+
+	var checks []use.Check
+	if err := json.Unmarshal(stdout.Bytes(), &checks); err != nil {
+		return []use.Check{c.unknown(cmdline, fmt.Sprintf("invalid JSON output: %v", err))}, nil
+	}
+
+	for i := range checks {
+		if checks[i].Resource == "" {
+			checks[i].Resource = c.name
+		}
+		if checks[i].Command == "" {
+			checks[i].Command = cmdline
+		}
+		if checks[i].Status == "" {
+			checks[i].Status = use.StatusUnknown
+		}
+		c.Trace(c.Name(), cmdline, checks[i].Value, checks[i].RawValue)
+	}
+	return checks, nil
+}
+
+// timeout returns the effective deadline for a run, or zero for none.
+func (c *Collector) timeout() time.Duration {
+	switch {
+	case c.Timeout == 0:
+		return DefaultTimeout
+	case c.Timeout < 0:
+		return 0
+	}
+	return c.Timeout
+}
+
+func (c *Collector) unknown(cmdline, reason string) use.Check {
+	return use.Check{
+		Resource:    c.name,
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: reason,
+		Command:     cmdline,
+	}
+}
@@ -0,0 +1,189 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const (
+	// deletedWarnPct is the share of a filesystem's free space held by
+	// deleted-but-open files that is flagged as a warning.
+	deletedWarnPct = 10.0
+	// deletedCritPct is the share flagged as an error.
+	deletedCritPct = 50.0
+)
+
+// fileID identifies a file by device and inode, so a file held open by
+// several descriptors or processes is only counted once.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// deletedHolder accumulates space held by one process.
+type deletedHolder struct {
+	pid   int
+	comm  string
+	bytes uint64
+}
+
+// deletedFilesCheck walks /proc/[pid]/fd for descriptors whose target has been
+// unlinked and reports the space they still pin. Without root only the current
+// user's processes are visible, so the total is a lower bound.
+func (c *Collector) deletedFilesCheck(mountPoints []string) (use.Check, bool) {
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return use.Check{}, false
+	}
+
+	// Free space per device, for judging significance
+	freeByDev := make(map[uint64]uint64)
+	mountByDev := make(map[uint64]string)
+	for _, mp := range mountPoints {
+		var st unix.Stat_t
+		var sfs unix.Statfs_t
+		if unix.Stat(mp, &st) != nil || unix.Statfs(mp, &sfs) != nil {
+			continue
+		}
+		if _, ok := freeByDev[st.Dev]; !ok {
+			freeByDev[st.Dev] = sfs.Bavail * uint64(sfs.Bsize)
+			mountByDev[st.Dev] = mp
+		}
+	}
+
+	seen := make(map[fileID]bool)
+	heldByDev := make(map[uint64]uint64)
+	holders := make(map[int]*deletedHolder)
+	var total uint64
+
+	for _, p := range procs {
+		pid, err := strconv.Atoi(p.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", p.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue // not ours, or the process exited
+		}
+		for _, fd := range fds {
+			fdPath := filepath.Join(fdDir, fd.Name())
+			target, err := os.Readlink(fdPath)
+			if err != nil || !strings.HasSuffix(target, " (deleted)") || strings.HasPrefix(target, "/memfd:") {
+				continue
+			}
+			var st unix.Stat_t
+			if unix.Stat(fdPath, &st) != nil || st.Mode&unix.S_IFMT != unix.S_IFREG {
+				continue
+			}
+			id := fileID{dev: st.Dev, ino: st.Ino}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			size := uint64(st.Blocks) * 512 // allocated, not apparent, size
+			total += size
+			heldByDev[st.Dev] += size
+			h, ok := holders[pid]
+			if !ok {
+				h = &deletedHolder{pid: pid, comm: readComm(pid)}
+				holders[pid] = h
+			}
+			h.bytes += size
+		}
+	}
+
+	// Worst share of free space across filesystems
+	var worstPct float64
+	var worstMount string
+	for dev, held := range heldByDev {
+		free, ok := freeByDev[dev]
+		if !ok {
+			continue
+		}
+		pct := 100.0
+		if free > 0 {
+			pct = float64(held) / float64(free) * 100
+		}
+		if pct > worstPct {
+			worstPct = pct
+			worstMount = mountByDev[dev]
+		}
+	}
+	c.Trace(c.Name(), "/proc/*/fd", fmt.Sprintf("%d deleted files, %d bytes", len(seen), total), float64(total))
+
+	check := use.Check{
+		Resource:    "Filesystem (deleted)",
+		Type:        use.Utilization,
+		Value:       formatBytes(total),
+		RawValue:    float64(total),
+		Unit:        use.UnitBytes,
+		ShowsRaw:    true,
+		Status:      use.StatusOK,
+		Description: "No significant space held by deleted-but-open files",
+		Command:     "ls -l /proc/*/fd | grep deleted",
+	}
+	if total == 0 {
+		return check, true
+	}
+
+	check.Description = fmt.Sprintf("%s held by deleted-but-open files (%.1f%% of free space on %s): %s",
+		formatBytes(total), worstPct, worstMount, topHolders(holders, 3))
+	if worstPct >= deletedCritPct {
+		check.Status = use.StatusError
+	} else if worstPct >= deletedWarnPct {
+		check.Status = use.StatusWarning
+	}
+	return check, true
+}
+
+// topHolders lists the n processes pinning the most deleted-file space.
+func topHolders(holders map[int]*deletedHolder, n int) string {
+	list := make([]*deletedHolder, 0, len(holders))
+	for _, h := range holders {
+		list = append(list, h)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].bytes > list[j].bytes })
+	if len(list) > n {
+		list = list[:n]
+	}
+	parts := make([]string, len(list))
+	for i, h := range list {
+		parts[i] = fmt.Sprintf("%s[%d] %s", h.comm, h.pid, formatBytes(h.bytes))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// readComm returns a process's command name, or "?" if unavailable.
+func readComm(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "?"
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// formatBytes formats bytes into human-readable format.
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,130 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danpilch/umd/pkg/cache"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const (
+	// fdHistoryLen is the number of samples kept in the state cache.
+	fdHistoryLen = 5
+	// fdHistoryMaxAge discards samples too old to describe the current trend.
+	fdHistoryMaxAge = time.Hour
+)
+
+// fdSample is a single system-wide FD count observation.
+type fdSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Allocated float64   `json:"allocated"`
+}
+
+// fdHistoryPath returns the state file holding FD samples between runs.
+func fdHistoryPath() string {
+	return filepath.Join(cache.StateDir(), "fd_samples.json")
+}
+
+// loadFDHistory reads prior FD samples from the state cache. A missing
+// file is no history, not an error.
+func loadFDHistory() ([]fdSample, error) {
+	data, err := os.ReadFile(fdHistoryPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var samples []fdSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", fdHistoryPath(), err)
+	}
+	return samples, nil
+}
+
+// saveFDHistory writes FD samples to the state cache.
+func saveFDHistory(samples []fdSample) error {
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return err
+	}
+	return cache.WriteFile(fdHistoryPath(), data)
+}
+
+// recordFDSample appends the current FD count to the cached history and
+// returns the updated history, oldest first. Errors reading or saving the
+// history are traced; an unreadable history starts afresh.
+func (c *Collector) recordFDSample(allocated float64, now time.Time) []fdSample {
+	prior, err := loadFDHistory()
+	if err != nil {
+		c.Trace(c.Name(), fdHistoryPath(), "unreadable, starting a new history: "+err.Error(), 0)
+	}
+	var history []fdSample
+	for _, s := range prior {
+		if now.Sub(s.Timestamp) <= fdHistoryMaxAge && s.Timestamp.Before(now) {
+			history = append(history, s)
+		}
+	}
+	history = append(history, fdSample{Timestamp: now, Allocated: allocated})
+	if len(history) > fdHistoryLen {
+		history = history[len(history)-fdHistoryLen:]
+	}
+	if err := saveFDHistory(history); err != nil {
+		c.Trace(c.Name(), fdHistoryPath(), "cannot save: "+err.Error(), float64(len(history)))
+	}
+	return history
+}
+
+// fdGrowthRate returns the FD growth rate in FDs/min across the history and
+// whether the count climbed between every consecutive pair of samples.
+func fdGrowthRate(history []fdSample) (float64, bool) {
+	if len(history) < 2 {
+		return 0, false
+	}
+	steady := true
+	for i := 1; i < len(history); i++ {
+		if history[i].Allocated <= history[i-1].Allocated {
+			steady = false
+		}
+	}
+	first, last := history[0], history[len(history)-1]
+	minutes := last.Timestamp.Sub(first.Timestamp).Minutes()
+	if minutes <= 0 {
+		return 0, false
+	}
+	return (last.Allocated - first.Allocated) / minutes, steady
+}
+
+// fdLeakCheck compares the current FD count against prior runs and flags a
+// steadily climbing count, even while absolute FD utilization is low.
+// A steady growth rate is judged against the "Filesystem (FDs)" errors limit.
+// Returns false until at least one prior sample is available.
+func (c *Collector) fdLeakCheck(thresholds use.Thresholds, allocated float64, command string) (use.Check, bool) {
+	history := c.recordFDSample(allocated, time.Now())
+	if len(history) < 2 {
+		return use.Check{}, false
+	}
+	rate, steady := fdGrowthRate(history)
+
+	status := use.StatusOK
+	if steady {
+		status = thresholds.EvaluateSaturationFor("Filesystem (FDs)", use.Errors, rate)
+	}
+	limits, _ := thresholds.SaturationLimitsFor("Filesystem (FDs)", use.Errors)
+	return use.Check{
+		Resource:    "Filesystem (FDs)",
+		Type:        use.Errors,
+		Value:       fmt.Sprintf("%+.0f FDs/min", rate),
+		RawValue:    rate,
+		Unit:        use.UnitPerMinute,
+		Status:      status,
+		Description: fmt.Sprintf("FD growth across %d samples (leak if steadily above %.0f/min)", len(history), limits.Warn),
+		Command:     command,
+	}, true
+}
@@ -1,8 +1,12 @@
 // Package filesystem provides filesystem inode and file descriptor metrics for the USE method.
 package filesystem
 
+import "github.com/danpilch/umd/pkg/use"
+
 // Collector gathers filesystem-level USE metrics (inodes, FDs).
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+}
 
 // New creates a new filesystem collector.
 func New() *Collector {
@@ -32,16 +32,21 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 
 		usedInodes := stat.Files - uint64(stat.Ffree)
 		inodePercent := (float64(usedInodes) / float64(stat.Files)) * 100
+		c.Trace(c.Name(), "statfs "+mp, fmt.Sprintf("files=%d ffree=%d", stat.Files, stat.Ffree), inodePercent)
 
-		status := thresholds.EvaluateUtilization(inodePercent)
+		status := thresholds.EvaluateUtilizationFor(fmt.Sprintf("Filesystem (%s)", mp), inodePercent)
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Filesystem (%s)", mp),
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%% inodes", inodePercent),
 			RawValue:    inodePercent,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "inodes",
 			Status:      status,
 			Description: fmt.Sprintf("Inode usage: %d/%d", usedInodes, stat.Files),
 			Command:     "statfs",
+			Kind:        "inodes",
 		})
 
 		// Errors: zero free inodes
@@ -51,6 +56,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 				Type:        use.Errors,
 				Value:       "0 free inodes",
 				RawValue:    1,
+				Unit:        use.UnitCount,
 				Status:      use.StatusError,
 				Description: "No free inodes available",
 				Command:     "statfs",
@@ -59,24 +65,26 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	}
 
 	// Saturation: FD utilization from sysctl
-	fdUtil, err := getFDUtilization()
+	fdUtil, numFiles, err := c.getFDUtilization()
 	if err == nil {
-		status := use.StatusOK
-		if fdUtil > 70 {
-			status = use.StatusWarning
-		}
-		if fdUtil > 90 {
-			status = use.StatusError
-		}
+		status := thresholds.EvaluateSaturationFor("Filesystem (FDs)", use.Saturation, fdUtil)
 		checks = append(checks, use.Check{
 			Resource:    "Filesystem (FDs)",
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.1f%% FDs used", fdUtil),
 			RawValue:    fdUtil,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "FDs used",
 			Status:      status,
 			Description: "File descriptor utilization",
 			Command:     "sysctl kern.maxfiles",
 		})
+
+		// Errors: FD leak (steadily climbing count across runs)
+		if leak, ok := c.fdLeakCheck(thresholds, numFiles, "sysctl kern.num_files"); ok {
+			checks = append(checks, leak)
+		}
 	}
 
 	return checks, nil
@@ -122,31 +130,34 @@ func getFilesystemMountPoints() []string {
 	return points
 }
 
-func getFDUtilization() (float64, error) {
+// getFDUtilization returns FD utilization percentage and the open file count.
+func (c *Collector) getFDUtilization() (float64, float64, error) {
 	// Get current number of open files
 	cmd := exec.Command("sysctl", "-n", "kern.num_files")
 	out, err := cmd.Output()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	numFiles, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	// Get max files
 	cmd = exec.Command("sysctl", "-n", "kern.maxfiles")
 	out, err = cmd.Output()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	maxFiles, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	if maxFiles == 0 {
-		return 0, fmt.Errorf("maxfiles is 0")
+		return 0, 0, fmt.Errorf("maxfiles is 0")
 	}
-	return (numFiles / maxFiles) * 100, nil
+	util := (numFiles / maxFiles) * 100
+	c.Trace(c.Name(), "sysctl kern.num_files kern.maxfiles", fmt.Sprintf("%.0f/%.0f", numFiles, maxFiles), util)
+	return util, numFiles, nil
 }
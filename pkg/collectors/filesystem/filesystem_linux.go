@@ -31,16 +31,21 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 
 		usedInodes := stat.Files - stat.Ffree
 		inodePercent := (float64(usedInodes) / float64(stat.Files)) * 100
+		c.Trace(c.Name(), "statfs "+mp, fmt.Sprintf("files=%d ffree=%d", stat.Files, stat.Ffree), inodePercent)
 
-		status := thresholds.EvaluateUtilization(inodePercent)
+		status := thresholds.EvaluateUtilizationFor(fmt.Sprintf("Filesystem (%s)", mp), inodePercent)
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Filesystem (%s)", mp),
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%% inodes", inodePercent),
 			RawValue:    inodePercent,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "inodes",
 			Status:      status,
 			Description: fmt.Sprintf("Inode usage: %d/%d", usedInodes, stat.Files),
 			Command:     "statfs",
+			Kind:        "inodes",
 		})
 
 		// Errors: zero free inodes
@@ -50,6 +55,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 				Type:        use.Errors,
 				Value:       "0 free inodes",
 				RawValue:    1,
+				Unit:        use.UnitCount,
 				Status:      use.StatusError,
 				Description: "No free inodes available",
 				Command:     "statfs",
@@ -57,25 +63,32 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		}
 	}
 
+	// Utilization: space pinned by deleted-but-open files
+	if check, ok := c.deletedFilesCheck(mountPoints); ok {
+		checks = append(checks, check)
+	}
+
 	// Saturation: FD utilization from /proc/sys/fs/file-nr
-	fdUtil, err := getFDUtilization()
+	fdUtil, allocated, err := c.getFDUtilization()
 	if err == nil {
-		status := use.StatusOK
-		if fdUtil > 70 {
-			status = use.StatusWarning
-		}
-		if fdUtil > 90 {
-			status = use.StatusError
-		}
+		status := thresholds.EvaluateSaturationFor("Filesystem (FDs)", use.Saturation, fdUtil)
 		checks = append(checks, use.Check{
 			Resource:    "Filesystem (FDs)",
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.1f%% FDs used", fdUtil),
 			RawValue:    fdUtil,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "FDs used",
 			Status:      status,
 			Description: "File descriptor utilization (allocated/max)",
 			Command:     "/proc/sys/fs/file-nr",
 		})
+
+		// Errors: FD leak (steadily climbing count across runs)
+		if leak, ok := c.fdLeakCheck(thresholds, allocated, "/proc/sys/fs/file-nr"); ok {
+			checks = append(checks, leak)
+		}
 	}
 
 	return checks, nil
@@ -117,19 +130,36 @@ func getFilesystemMountPoints() []string {
 	return points
 }
 
-func getFDUtilization() (float64, error) {
+// getFDUtilization returns FD utilization percentage and the allocated FD count.
+func (c *Collector) getFDUtilization() (float64, float64, error) {
 	data, err := os.ReadFile("/proc/sys/fs/file-nr")
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	fields := strings.Fields(string(data))
 	if len(fields) < 3 {
-		return 0, fmt.Errorf("unexpected file-nr format")
+		return 0, 0, fmt.Errorf("unexpected file-nr format")
 	}
 	allocated, _ := strconv.ParseFloat(fields[0], 64)
 	max, _ := strconv.ParseFloat(fields[2], 64)
 	if max == 0 {
-		return 0, fmt.Errorf("max FDs is 0")
+		return 0, 0, fmt.Errorf("max FDs is 0")
+	}
+	util := (allocated / max) * 100
+	c.Trace(c.Name(), "/proc/sys/fs/file-nr", strings.TrimSpace(string(data)), util)
+	return util, allocated, nil
+}
+
+// Preflight reports whether other users' /proc/[pid]/fd can be inspected.
+func (c *Collector) Preflight() []use.Access {
+	access := use.Access{
+		Collector:  c.Name(),
+		Source:     "/proc/[pid]/fd",
+		Accessible: use.IsRoot(),
+		Impact:     "Deleted-but-open file space only counted for your own processes",
+	}
+	if !access.Accessible {
+		access.Reason = use.ReasonPermission
 	}
-	return (allocated / max) * 100, nil
+	return []use.Access{access}
 }
@@ -0,0 +1,19 @@
+// Package gpu provides GPU utilization and memory saturation metrics.
+package gpu
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers GPU USE metrics.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new GPU collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "GPU"
+}
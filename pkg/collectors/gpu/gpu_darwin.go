@@ -0,0 +1,182 @@
+//go:build darwin
+
+package gpu
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Collect gathers GPU USE metrics on Apple Silicon. Utilization needs
+// powermetrics (root); memory saturation is read from ioreg without privileges.
+// Intel Macs produce no checks.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	if runtime.GOARCH != "arm64" {
+		return nil, nil
+	}
+	checks := make([]use.Check, 0, 2)
+
+	// Utilization: GPU active residency from powermetrics
+	util, freq, err := c.getActiveResidency()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "GPU",
+			Type:        use.Utilization,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "powermetrics --samplers gpu_power",
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "GPU",
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f%%", util),
+			RawValue:    util,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("GPU", util),
+			Description: fmt.Sprintf("GPU active residency (%.0f MHz)", freq),
+			Command:     "powermetrics --samplers gpu_power",
+		})
+	}
+
+	// Saturation: GPU-resident memory as a share of unified memory
+	inUse, err := c.getMemoryInUse()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "GPU",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "ioreg -c IOAccelerator",
+		})
+	} else if total := getMemSize(); total > 0 {
+		pct := float64(inUse) / float64(total) * 100
+		checks = append(checks, use.Check{
+			Resource:    "GPU",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.1f GB (%.1f%% of RAM)", float64(inUse)/1e9, pct),
+			RawValue:    pct,
+			Unit:        use.UnitPercent,
+			Status:      thresholds.EvaluateSaturationFor("GPU", use.Saturation, pct),
+			Description: "GPU memory in use as a share of unified memory",
+			Command:     "ioreg -c IOAccelerator",
+		})
+	}
+
+	return checks, nil
+}
+
+// getActiveResidency returns GPU active residency % and frequency in MHz.
+func (c *Collector) getActiveResidency() (float64, float64, error) {
+	if _, err := exec.LookPath("powermetrics"); err != nil {
+		return 0, 0, fmt.Errorf("powermetrics not available")
+	}
+	if !use.IsRoot() {
+		return 0, 0, fmt.Errorf("powermetrics requires root (run with sudo)")
+	}
+
+	out, err := exec.Command("powermetrics", "--samplers", "gpu_power", "-i", "500", "-n", "1").Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("powermetrics failed: %v", err)
+	}
+
+	var util, freq float64
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "GPU HW active residency:"), strings.HasPrefix(line, "GPU active residency:"):
+			_, val, _ := strings.Cut(line, ":")
+			util = parseLeadingFloat(val)
+			found = true
+			c.Trace(c.Name(), "powermetrics", line, util)
+		case strings.HasPrefix(line, "GPU HW active frequency:"), strings.HasPrefix(line, "GPU active frequency:"):
+			_, val, _ := strings.Cut(line, ":")
+			freq = parseLeadingFloat(val)
+			c.Trace(c.Name(), "powermetrics", line, freq)
+		}
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("no GPU residency in powermetrics output")
+	}
+	return util, freq, nil
+}
+
+// getMemoryInUse returns the GPU's in-use system memory in bytes from the
+// IOAccelerator PerformanceStatistics dictionary.
+func (c *Collector) getMemoryInUse() (uint64, error) {
+	out, err := exec.Command("ioreg", "-r", "-d", "1", "-w", "0", "-c", "IOAccelerator").Output()
+	if err != nil {
+		return 0, fmt.Errorf("ioreg failed: %v", err)
+	}
+
+	const key = `"In use system memory"=`
+	for _, line := range strings.Split(string(out), "\n") {
+		idx := strings.Index(line, key)
+		if idx < 0 {
+			continue
+		}
+		rest := line[idx+len(key):]
+		end := strings.IndexAny(rest, ",}")
+		if end >= 0 {
+			rest = rest[:end]
+		}
+		v, err := strconv.ParseUint(strings.TrimSpace(rest), 10, 64)
+		if err != nil {
+			continue
+		}
+		c.Trace(c.Name(), "ioreg IOAccelerator", key+rest, float64(v))
+		return v, nil
+	}
+	return 0, fmt.Errorf("GPU memory statistics not found in ioreg")
+}
+
+// getMemSize returns physical memory in bytes, or 0 if unavailable.
+func getMemSize() uint64 {
+	out, err := exec.Command("sysctl", "-n", "hw.memsize").Output()
+	if err != nil {
+		return 0
+	}
+	v, _ := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+	return v
+}
+
+// parseLeadingFloat parses the first number in a string like " 45.23%".
+func parseLeadingFloat(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(fields[0], "%"), 64)
+	return v
+}
+
+// Preflight reports whether powermetrics can be run for GPU utilization.
+func (c *Collector) Preflight() []use.Access {
+	if runtime.GOARCH != "arm64" {
+		return nil
+	}
+	access := use.Access{
+		Collector:  c.Name(),
+		Source:     "powermetrics",
+		Accessible: true,
+		Impact:     "GPU utilization reported as unknown (requires root)",
+	}
+	if _, err := exec.LookPath("powermetrics"); err != nil {
+		access.Accessible, access.Reason = false, use.ReasonMissing
+	} else if !use.IsRoot() {
+		access.Accessible, access.Reason = false, use.ReasonPermission
+	}
+	return []use.Access{access}
+}
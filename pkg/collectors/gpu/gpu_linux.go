@@ -0,0 +1,11 @@
+//go:build linux
+
+package gpu
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on Linux; the GPU collector currently supports
+// Apple Silicon via powermetrics and ioreg.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
@@ -0,0 +1,38 @@
+// Package irq provides hardware interrupt and softirq rate metrics for the
+// USE method.
+package irq
+
+import "github.com/danpilch/umd/pkg/use"
+
+// DefaultTopSources is how many of the busiest interrupt sources are
+// reported when TopSources is zero.
+const DefaultTopSources = 5
+
+// Collector gathers interrupt rates from /proc/interrupts and /proc/softirqs.
+// Interrupt load shows up as latency and as system time on the cores
+// handling it rather than in overall CPU utilization.
+type Collector struct {
+	use.TraceHook
+
+	// TopSources limits how many individual interrupt sources are reported
+	// alongside the totals. Zero uses DefaultTopSources.
+	TopSources int
+}
+
+// New creates a new IRQ collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "IRQ"
+}
+
+// topSources returns the effective number of sources to report.
+func (c *Collector) topSources() int {
+	if c.TopSources <= 0 {
+		return DefaultTopSources
+	}
+	return c.TopSources
+}
@@ -0,0 +1,11 @@
+//go:build darwin
+
+package irq
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which exposes no per-source interrupt
+// counters without root and DTrace.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
@@ -0,0 +1,251 @@
+//go:build linux
+
+package irq
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// sampleInterval is the window between the two counter reads.
+const sampleInterval = 100 * time.Millisecond
+
+// counters holds per-CPU interrupt counts for one source, e.g. an IRQ line
+// ("virtio0-input.0", "LOC") or a softirq ("NET_RX").
+type counters struct {
+	perCPU []uint64
+}
+
+func (c counters) total() uint64 {
+	var t uint64
+	for _, n := range c.perCPU {
+		t += n
+	}
+	return t
+}
+
+// source is one interrupt source's rate over the sample window.
+type source struct {
+	name    string
+	rate    float64 // per second, all CPUs
+	topCPU  int     // CPU that handled the most
+	topRate float64 // per second on topCPU
+}
+
+// Collect gathers interrupt and softirq rates on Linux. Totals are reported
+// as "IRQ" and "IRQ (softirq)"; the busiest individual sources follow as
+// "IRQ (<source>)" with the CPU that handled most of them, which explains a
+// hot core in per-core CPU utilization.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	hard1, err := readInterrupts("/proc/interrupts")
+	if err != nil {
+		return nil, err
+	}
+	soft1, softErr := readInterrupts("/proc/softirqs")
+	start := time.Now()
+
+	time.Sleep(sampleInterval)
+
+	hard2, err := readInterrupts("/proc/interrupts")
+	if err != nil {
+		return nil, err
+	}
+	soft2, err := readInterrupts("/proc/softirqs")
+	if softErr == nil {
+		softErr = err
+	}
+	elapsed := time.Since(start)
+
+	checks := make([]use.Check, 0, 2+c.topSources())
+
+	hard := rates(hard1, hard2, elapsed)
+	hardTotal := sumRates(hard)
+	c.Trace(c.Name(), "/proc/interrupts", fmt.Sprintf("%d sources", len(hard2)), hardTotal)
+	checks = append(checks, use.Check{
+		Resource:    "IRQ",
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("%.0f/s", hardTotal),
+		RawValue:    hardTotal,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Status:      thresholds.EvaluateSaturationFor("IRQ", use.Saturation, hardTotal),
+		Description: "Hardware interrupts/s, all CPUs",
+		Command:     "/proc/interrupts",
+	})
+
+	var soft []source
+	if softErr != nil {
+		checks = append(checks, use.Check{
+			Resource:    "IRQ (softirq)",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: softErr.Error(),
+			Command:     "/proc/softirqs",
+		})
+	} else {
+		soft = rates(soft1, soft2, elapsed)
+		softTotal := sumRates(soft)
+		c.Trace(c.Name(), "/proc/softirqs", fmt.Sprintf("%d sources", len(soft2)), softTotal)
+		checks = append(checks, use.Check{
+			Resource:    "IRQ (softirq)",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.0f/s", softTotal),
+			RawValue:    softTotal,
+			Unit:        use.UnitPerSecond,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateSaturationFor("IRQ (softirq)", use.Saturation, softTotal),
+			Description: "Softirqs/s, all CPUs",
+			Command:     "/proc/softirqs",
+		})
+	}
+
+	// Busiest sources across both files
+	for i := range soft {
+		soft[i].name = "softirq " + soft[i].name
+	}
+	all := append(hard, soft...)
+	sort.Slice(all, func(i, j int) bool { return all[i].rate > all[j].rate })
+	for _, s := range all[:min(len(all), c.topSources())] {
+		if s.rate == 0 {
+			break
+		}
+		resource := fmt.Sprintf("IRQ (%s)", s.name)
+		command := "/proc/interrupts"
+		if strings.HasPrefix(s.name, "softirq ") {
+			command = "/proc/softirqs"
+		}
+		checks = append(checks, use.Check{
+			Resource:    resource,
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.0f/s", s.rate),
+			RawValue:    s.rate,
+			Unit:        use.UnitPerSecond,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateSaturationFor(resource, use.Saturation, s.rate),
+			Description: fmt.Sprintf("Interrupts/s; %.0f%% on CPU%d", s.topRate/s.rate*100, s.topCPU),
+			Command:     command,
+		})
+	}
+
+	return checks, nil
+}
+
+// rates converts two counter snapshots into per-source rates.
+func rates(before, after map[string]counters, elapsed time.Duration) []source {
+	sources := make([]source, 0, len(after))
+	for name, c2 := range after {
+		c1, ok := before[name]
+		if !ok {
+			continue
+		}
+		s := source{name: name}
+		for cpu, n2 := range c2.perCPU {
+			if cpu >= len(c1.perCPU) || n2 < c1.perCPU[cpu] {
+				continue
+			}
+			r := use.PerSecond(n2-c1.perCPU[cpu], elapsed)
+			s.rate += r
+			if r > s.topRate {
+				s.topCPU, s.topRate = cpu, r
+			}
+		}
+		sources = append(sources, s)
+	}
+	return sources
+}
+
+func sumRates(sources []source) float64 {
+	var t float64
+	for _, s := range sources {
+		t += s.rate
+	}
+	return t
+}
+
+// readInterrupts parses /proc/interrupts or /proc/softirqs: a header of CPU
+// columns, then one row per source:
+//
+//	           CPU0       CPU1
+//	 24:          1          0  IO-APIC   5-edge      ACPI:Ged
+//	LOC:     104572      98211  Local timer interrupts
+//	NET_RX:    7343       6120
+//
+// Numbered IRQs are named by their device (the last field), with the number
+// added when several lines share a device name; named rows (LOC, NET_RX)
+// keep their label.
+func readInterrupts(path string) (map[string]counters, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // one column per CPU
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	ncpu := len(strings.Fields(scanner.Text()))
+
+	type row struct {
+		label, device string
+		c             counters
+	}
+	var rows []row
+	devices := make(map[string]int)
+	for scanner.Scan() {
+		label, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		label = strings.TrimSpace(label)
+		fields := strings.Fields(rest)
+
+		var c counters
+		i := 0
+		for ; i < len(fields) && i < ncpu; i++ {
+			n, err := strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				break
+			}
+			c.perCPU = append(c.perCPU, n)
+		}
+		if len(c.perCPU) == 0 {
+			continue
+		}
+
+		r := row{label: label, c: c}
+		if _, err := strconv.Atoi(label); err == nil && i < len(fields) {
+			r.device = fields[len(fields)-1]
+			devices[r.device]++
+		}
+		rows = append(rows, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]counters, len(rows))
+	for _, r := range rows {
+		name := r.label
+		switch {
+		case r.device != "" && devices[r.device] == 1:
+			name = r.device
+		case r.device != "":
+			name = r.device + " " + r.label
+		}
+		result[name] = r.c
+	}
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package collectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/collectors/exec"
+	"github.com/danpilch/umd/pkg/config"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Manifest declares the exact set and order of collectors to run, plus
+// optional thresholds, so a survey can be version-controlled and shared.
+// Thresholds has the config file's schema, so a manifest can set anything
+// the config file can, including saturation limits and disabled collectors.
+type Manifest struct {
+	Thresholds *config.File    `json:"thresholds,omitempty"`
+	Collectors []ManifestEntry `json:"collectors"`
+}
+
+// manifestDoc is the decoded form of a manifest. Thresholds is kept raw so
+// config.Parse decodes it exactly as it decodes the config file.
+type manifestDoc struct {
+	Thresholds json.RawMessage `json:"thresholds,omitempty"`
+	Collectors []ManifestEntry `json:"collectors"`
+}
+
+// ManifestEntry is a single collector. Built-ins are referenced by name
+// (e.g. "cpu"); entries with Exec define an external plugin collector.
+type ManifestEntry struct {
+	Name    string   `json:"name"`
+	Exec    []string `json:"exec,omitempty"`
+	Timeout string   `json:"timeout,omitempty"` // exec only, e.g. "5s"
+	Cache   string   `json:"cache,omitempty"`   // reuse results for this long, e.g. "5s"
+
+	// CriticalUnits overrides the systemd collector's critical unit prefixes.
+	CriticalUnits []string `json:"critical_units,omitempty"`
+
+	// PerCore adds per-core utilization checks to the cpu collector.
+	PerCore bool `json:"per_core,omitempty"`
+
+	// Iostat makes the disk collector read %util and aqu-sz from iostat -x.
+	Iostat bool `json:"iostat,omitempty"`
+}
+
+// LoadManifest reads a collector manifest from a YAML or JSON file. A path
+// of "-" reads from stdin, so generated manifests can be piped in.
+func LoadManifest(path string) (*Manifest, error) {
+	if path == "-" {
+		return ReadManifest(os.Stdin, "stdin")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest: %w", err)
+	}
+	defer f.Close()
+	return ReadManifest(f, path)
+}
+
+// ReadManifest decodes a collector manifest from r; source names it in errors.
+// A document starting with "{" is JSON; anything else is YAML, converted the
+// same way as the config file so both formats share the json field names.
+func ReadManifest(r io.Reader, source string) (*Manifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest: %w", err)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		if data, err = config.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("cannot parse manifest: %w", err)
+		}
+	}
+	var doc manifestDoc
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("cannot parse manifest: %w", err)
+	}
+	if len(doc.Collectors) == 0 {
+		return nil, fmt.Errorf("manifest %s defines no collectors", source)
+	}
+
+	m := &Manifest{Collectors: doc.Collectors}
+	if len(doc.Thresholds) > 0 && string(doc.Thresholds) != "null" {
+		if m.Thresholds, err = config.Parse(doc.Thresholds, true); err != nil {
+			return nil, fmt.Errorf("cannot parse manifest thresholds: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Registry builds a registry containing the manifest's collectors in order,
+// less any its thresholds list as disabled.
+func (m *Manifest) Registry() (*Registry, error) {
+	r := NewRegistry()
+	for i, e := range m.Collectors {
+		c, err := e.build()
+		if err != nil {
+			return nil, fmt.Errorf("manifest collector %d: %w", i+1, err)
+		}
+		r.Register(c)
+	}
+	if m.Thresholds != nil {
+		if err := r.Disable(m.Thresholds.Disabled...); err != nil {
+			return nil, fmt.Errorf("manifest disabled: %w", err)
+		}
+	}
+	return r, nil
+}
+
+func (e ManifestEntry) build() (Collector, error) {
+	c, err := e.buildCollector()
+	if err != nil || e.Cache == "" {
+		return c, err
+	}
+	ttl, err := time.ParseDuration(e.Cache)
+	if err != nil || ttl < 0 {
+		return nil, fmt.Errorf("%s: invalid cache duration %q", e.Name, e.Cache)
+	}
+	return NewCachingCollector(c, ttl), nil
+}
+
+func (e ManifestEntry) buildCollector() (Collector, error) {
+	if e.Name == "" {
+		return nil, fmt.Errorf("missing name")
+	}
+
+	if len(e.Exec) > 0 {
+		c := exec.New(e.Name, e.Exec)
+		if e.Timeout != "" {
+			d, err := time.ParseDuration(e.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid timeout %q", e.Name, e.Timeout)
+			}
+			c.Timeout = d
+		}
+		return c, nil
+	}
+
+	newFn, ok := builtins[strings.ToLower(e.Name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown collector %q", e.Name)
+	}
+	return newFn(e), nil
+}
+
+// ApplyThresholds layers the manifest's thresholds over base, the same way
+// config.File.Apply layers the config file.
+func (m *Manifest) ApplyThresholds(base use.Thresholds) use.Thresholds {
+	if m.Thresholds == nil {
+		return base
+	}
+	return m.Thresholds.Apply(base)
+}
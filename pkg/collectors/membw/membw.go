@@ -0,0 +1,19 @@
+// Package membw provides unified memory bandwidth saturation metrics on Apple Silicon.
+package membw
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers memory bandwidth USE metrics.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new memory bandwidth collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "MemBW"
+}
@@ -0,0 +1,181 @@
+//go:build darwin
+
+package membw
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// peakBandwidthGBs maps Apple Silicon chip names to peak unified memory
+// bandwidth in GB/s. Longer names are matched first.
+var peakBandwidthGBs = []struct {
+	chip string
+	gbs  float64
+}{
+	{"M1 Ultra", 800}, {"M1 Max", 400}, {"M1 Pro", 200}, {"M1", 68.25},
+	{"M2 Ultra", 800}, {"M2 Max", 400}, {"M2 Pro", 200}, {"M2", 100},
+	{"M3 Ultra", 819}, {"M3 Max", 400}, {"M3 Pro", 150}, {"M3", 100},
+	{"M4 Max", 546}, {"M4 Pro", 273}, {"M4", 120},
+}
+
+// bandwidthStats holds values parsed from powermetrics output.
+type bandwidthStats struct {
+	ReadMBs      float64
+	WriteMBs     float64
+	GPUResidency float64 // percent
+	ANEPowerMW   float64
+}
+
+// Collect gathers memory bandwidth USE metrics on Apple Silicon.
+// Requires powermetrics and root; degrades to unknown otherwise.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	if runtime.GOARCH != "arm64" {
+		return nil, nil
+	}
+
+	unknown := func(desc string) []use.Check {
+		return []use.Check{{
+			Resource:    "MemBW",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: desc,
+			Command:     "powermetrics",
+		}}
+	}
+
+	if _, err := exec.LookPath("powermetrics"); err != nil {
+		return unknown("powermetrics not available"), nil
+	}
+	if !use.IsRoot() {
+		return unknown("powermetrics requires root (run with sudo)"), nil
+	}
+
+	stats, err := c.readPowermetrics()
+	if err != nil {
+		return unknown(err.Error()), nil
+	}
+
+	totalGBs := (stats.ReadMBs + stats.WriteMBs) / 1000
+	chip, peak := chipPeakBandwidth()
+	context := fmt.Sprintf("read %.0f MB/s, write %.0f MB/s; GPU %.1f%% active, ANE %.0f mW",
+		stats.ReadMBs, stats.WriteMBs, stats.GPUResidency, stats.ANEPowerMW)
+
+	if peak == 0 {
+		return []use.Check{{
+			Resource:    "MemBW",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.1f GB/s", totalGBs),
+			RawValue:    totalGBs * 1e9,
+			Unit:        use.UnitBytesPerSecond,
+			ShowsRaw:    true,
+			Status:      use.StatusOK, // Can't determine % without known peak bandwidth
+			Description: "Unified memory bandwidth (unknown chip peak): " + context,
+			Command:     "powermetrics --samplers bandwidth",
+		}}, nil
+	}
+
+	pct := totalGBs / peak * 100
+	return []use.Check{{
+		Resource:    "MemBW",
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("%.1f GB/s (%.1f%% of peak)", totalGBs, pct),
+		RawValue:    pct,
+		Unit:        use.UnitPercent,
+		Status:      thresholds.EvaluateSaturationFor("MemBW", use.Saturation, pct),
+		Description: fmt.Sprintf("Unified memory bandwidth vs %s peak %.0f GB/s: %s", chip, peak, context),
+		Command:     "powermetrics --samplers bandwidth",
+	}}, nil
+}
+
+// readPowermetrics takes a single short powermetrics sample.
+func (c *Collector) readPowermetrics() (bandwidthStats, error) {
+	cmd := exec.Command("powermetrics",
+		"--samplers", "bandwidth,gpu_power,ane_power",
+		"-i", "500", "-n", "1")
+	out, err := cmd.Output()
+	if err != nil {
+		return bandwidthStats{}, fmt.Errorf("powermetrics failed: %v", err)
+	}
+
+	var stats bandwidthStats
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "DCS RD:"):
+			stats.ReadMBs = parseLeadingFloat(strings.TrimPrefix(line, "DCS RD:"))
+			found = true
+			c.Trace(c.Name(), "powermetrics", line, stats.ReadMBs)
+		case strings.HasPrefix(line, "DCS WR:"):
+			stats.WriteMBs = parseLeadingFloat(strings.TrimPrefix(line, "DCS WR:"))
+			found = true
+			c.Trace(c.Name(), "powermetrics", line, stats.WriteMBs)
+		case strings.HasPrefix(line, "GPU HW active residency:"), strings.HasPrefix(line, "GPU active residency:"):
+			_, val, _ := strings.Cut(line, ":")
+			stats.GPUResidency = parseLeadingFloat(val)
+			c.Trace(c.Name(), "powermetrics", line, stats.GPUResidency)
+		case strings.HasPrefix(line, "ANE Power:"):
+			stats.ANEPowerMW = parseLeadingFloat(strings.TrimPrefix(line, "ANE Power:"))
+			c.Trace(c.Name(), "powermetrics", line, stats.ANEPowerMW)
+		}
+	}
+
+	if !found {
+		return stats, fmt.Errorf("no bandwidth counters in powermetrics output")
+	}
+	return stats, nil
+}
+
+// chipPeakBandwidth returns the chip name and its peak bandwidth in GB/s, or 0 if unknown.
+func chipPeakBandwidth() (string, float64) {
+	out, err := exec.Command("sysctl", "-n", "machdep.cpu.brand_string").Output()
+	if err != nil {
+		return "", 0
+	}
+	brand := strings.TrimSpace(string(out))
+	for _, p := range peakBandwidthGBs {
+		if strings.Contains(brand, p.chip) {
+			return p.chip, p.gbs
+		}
+	}
+	return brand, 0
+}
+
+// parseLeadingFloat parses the first number in a string like " 1234.5 MB/s".
+func parseLeadingFloat(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(fields[0], "%"), 64)
+	return v
+}
+
+// Preflight reports whether powermetrics can be run.
+func (c *Collector) Preflight() []use.Access {
+	if runtime.GOARCH != "arm64" {
+		return nil
+	}
+	access := use.Access{
+		Collector:  c.Name(),
+		Source:     "powermetrics",
+		Accessible: true,
+		Impact:     "Memory bandwidth reported as unknown (requires root)",
+	}
+	if _, err := exec.LookPath("powermetrics"); err != nil {
+		access.Accessible, access.Reason = false, use.ReasonMissing
+	} else if !use.IsRoot() {
+		access.Accessible, access.Reason = false, use.ReasonPermission
+	}
+	return []use.Access{access}
+}
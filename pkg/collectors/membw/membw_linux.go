@@ -0,0 +1,11 @@
+//go:build linux
+
+package membw
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on Linux; unified memory bandwidth via
+// powermetrics is specific to Apple Silicon.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
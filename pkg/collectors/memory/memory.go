@@ -1,8 +1,12 @@
 // Package memory provides memory metrics collection for the USE method.
 package memory
 
+import "github.com/danpilch/umd/pkg/use"
+
 // Collector gathers memory-related USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+}
 
 // New creates a new memory collector.
 func New() *Collector {
@@ -14,4 +18,4 @@ func (c *Collector) Name() string {
 	return "Memory"
 }
 
-// Collect gathers memory metrics. Platform-specific implementation in memory_linux.go and memory_darwin.go.
+// Collect gathers memory metrics. Platform-specific implementation in memory_linux.go, memory_darwin.go, memory_windows.go and memory_freebsd.go.
@@ -5,8 +5,8 @@ package memory
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 	"unsafe"
@@ -24,6 +24,12 @@ import "C"
 
 // Collect gathers memory USE metrics on macOS.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but kills the commands it runs once ctx
+// is done.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
 	// Utilization
@@ -43,14 +49,16 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.1f%%", util),
 			RawValue:    util,
-			Status:      thresholds.EvaluateUtilization(util),
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("Memory", util),
 			Description: "Memory used percentage",
 			Command:     "host_statistics64",
 		})
 	}
 
 	// Saturation (vm_stat pageouts)
-	sat, satDesc, err := c.getSaturation()
+	sat, satDesc, err := c.getSaturation(ctx)
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Memory",
@@ -70,6 +78,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Saturation,
 			Value:       satDesc,
 			RawValue:    sat,
+			Unit:        use.UnitCount,
 			Status:      status,
 			Description: "Page outs indicate memory pressure",
 			Command:     "vm_stat",
@@ -77,12 +86,14 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	}
 
 	// Errors (from system.log - best effort)
-	errCount := c.getErrors()
+	errCount := c.getErrors(ctx)
 	checks = append(checks, use.Check{
 		Resource:    "Memory",
 		Type:        use.Errors,
 		Value:       fmt.Sprintf("%d", errCount),
 		RawValue:    float64(errCount),
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
 		Status:      use.EvaluateErrors(errCount),
 		Description: "Memory errors from system log",
 		Command:     "log show",
@@ -127,13 +138,14 @@ func (c *Collector) getUtilization() (float64, error) {
 	usedMem := uint64(totalMem) - freeMem
 
 	util := (float64(usedMem) / float64(totalMem)) * 100
+	c.Trace(c.Name(), "host_statistics64", fmt.Sprintf("hw.memsize=%d free=%d inactive=%d purgeable=%d speculative=%d pages",
+		uint64(totalMem), freePages, inactivePages, purgeablePages, speculativePages), util)
 	return util, nil
 }
 
 // getSaturation checks for pageouts indicating memory pressure.
-func (c *Collector) getSaturation() (float64, string, error) {
-	cmd := exec.Command("vm_stat")
-	out, err := cmd.Output()
+func (c *Collector) getSaturation(ctx context.Context) (float64, string, error) {
+	out, err := use.CommandOutput(ctx, "vm_stat")
 	if err != nil {
 		return 0, "", err
 	}
@@ -147,6 +159,7 @@ func (c *Collector) getSaturation() (float64, string, error) {
 			if len(fields) >= 2 {
 				pageouts, _ = strconv.ParseUint(strings.TrimSuffix(fields[1], "."), 10, 64)
 			}
+			c.Trace(c.Name(), "vm_stat", line, float64(pageouts))
 		}
 	}
 
@@ -155,22 +168,32 @@ func (c *Collector) getSaturation() (float64, string, error) {
 }
 
 // getErrors checks for memory-related errors in system logs.
-func (c *Collector) getErrors() int64 {
+func (c *Collector) getErrors(ctx context.Context) int64 {
 	// Best effort - check for memory pressure and jetsam events
-	cmd := exec.Command("log", "show", "--predicate", "(eventMessage contains 'jetsam') OR (eventMessage contains 'memory pressure')", "--last", "1h", "--style", "compact")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	out, err := use.CommandOutput(ctx, "log", "show", "--predicate", "(eventMessage contains 'jetsam') OR (eventMessage contains 'memory pressure')", "--last", "1h", "--style", "compact")
 	if err != nil {
 		return 0
 	}
 
-	lines := strings.Split(out.String(), "\n")
+	lines := strings.Split(string(out), "\n")
 	count := int64(0)
 	for _, line := range lines {
 		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Timestamp") {
 			count++
 		}
 	}
+	c.Trace(c.Name(), "log show", fmt.Sprintf("%d jetsam/memory pressure lines", count), float64(count))
 	return count
 }
+
+// Preflight reports whether the unified log can be queried.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.RunAccess("log", "show", "--last", "1s", "--style", "compact")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "log show",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "Jetsam / memory pressure events always reported as 0",
+	}}
+}
@@ -0,0 +1,131 @@
+//go:build freebsd
+
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Collect gathers memory USE metrics on FreeBSD from the vm.stats.vm page
+// counters. Saturation is pages swapped out since boot, the counterpart of
+// the macOS pageout count. Out-of-memory events are reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0, 3)
+
+	// Utilization
+	util, err := c.getUtilization()
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "Memory",
+			Type:        use.Utilization,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "sysctl vm.stats.vm",
+		})
+	} else {
+		checks = append(checks, use.Check{
+			Resource:    "Memory",
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f%%", util),
+			RawValue:    util,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Status:      thresholds.EvaluateUtilizationFor("Memory", util),
+			Description: "Memory used percentage (excluding free and inactive pages)",
+			Command:     "sysctl vm.stats.vm",
+		})
+	}
+
+	// Saturation (swap-outs)
+	swapouts, err := sysctlCounter("vm.stats.vm.v_swappgsout")
+	if err != nil {
+		checks = append(checks, use.Check{
+			Resource:    "Memory",
+			Type:        use.Saturation,
+			Value:       "unknown",
+			Status:      use.StatusUnknown,
+			Description: err.Error(),
+			Command:     "sysctl vm.stats.vm.v_swappgsout",
+		})
+	} else {
+		c.Trace(c.Name(), "sysctl vm.stats.vm.v_swappgsout", fmt.Sprintf("%d", swapouts), float64(swapouts))
+		status := use.StatusOK
+		if swapouts > 0 {
+			status = use.StatusWarning
+		}
+		checks = append(checks, use.Check{
+			Resource:    "Memory",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%d swap-outs", swapouts),
+			RawValue:    float64(swapouts),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Label:       "swap-outs",
+			Status:      status,
+			Description: "Pages swapped out indicate memory pressure",
+			Command:     "sysctl vm.stats.vm.v_swappgsout",
+		})
+	}
+
+	checks = append(checks, use.Check{
+		Resource:    "Memory",
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: "Out-of-memory events are not collected on FreeBSD",
+		Command:     "-",
+	})
+
+	return checks, nil
+}
+
+// getUtilization returns the share of pages that can't be reclaimed without
+// writing them out. Free and inactive pages count as available; laundry
+// pages are dirty and count as used. v_cache_count was removed in FreeBSD 12
+// and is treated as zero when absent.
+func (c *Collector) getUtilization() (float64, error) {
+	total, err := sysctlCounter("vm.stats.vm.v_page_count")
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("sysctl vm.stats.vm.v_page_count reported no pages")
+	}
+	free, err := sysctlCounter("vm.stats.vm.v_free_count")
+	if err != nil {
+		return 0, err
+	}
+	inactive, err := sysctlCounter("vm.stats.vm.v_inactive_count")
+	if err != nil {
+		return 0, err
+	}
+	cache, _ := sysctlCounter("vm.stats.vm.v_cache_count")
+
+	available := min(free+inactive+cache, total)
+	util := float64(total-available) / float64(total) * 100
+	c.Trace(c.Name(), "sysctl vm.stats.vm", fmt.Sprintf("page_count: %d, free: %d, inactive: %d, cache: %d",
+		total, free, inactive, cache), util)
+	return util, nil
+}
+
+// sysctlCounter reads an unsigned integer sysctl. The vm.stats counters
+// changed from 32 to 64 bits across releases, so both widths are accepted.
+func sysctlCounter(name string) (uint64, error) {
+	buf, err := unix.SysctlRaw(name)
+	if err != nil {
+		return 0, fmt.Errorf("sysctl %s: %w", name, err)
+	}
+	switch len(buf) {
+	case 8:
+		return binary.NativeEndian.Uint64(buf), nil
+	case 4:
+		return uint64(binary.NativeEndian.Uint32(buf)), nil
+	}
+	return 0, fmt.Errorf("sysctl %s: unexpected size %d", name, len(buf))
+}
@@ -28,7 +28,9 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		Type:        use.Utilization,
 		Value:       fmt.Sprintf("%.1f%%", util),
 		RawValue:    util,
-		Status:      thresholds.EvaluateUtilization(util),
+		Unit:        use.UnitPercent,
+		ShowsRaw:    true,
+		Status:      thresholds.EvaluateUtilizationFor("Memory", util),
 		Description: "Memory used percentage",
 		Command:     "/proc/meminfo",
 	})
@@ -44,6 +46,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		Type:        use.Saturation,
 		Value:       satDesc,
 		RawValue:    sat,
+		Unit:        use.UnitPercent,
 		Status:      satStatus,
 		Description: "Swap usage indicates memory pressure",
 		Command:     "/proc/meminfo",
@@ -56,6 +59,8 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		Type:        use.Errors,
 		Value:       fmt.Sprintf("%d", errCount),
 		RawValue:    float64(errCount),
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
 		Status:      use.EvaluateErrors(errCount),
 		Description: "OOM killer invocations",
 		Command:     "dmesg",
@@ -106,7 +111,9 @@ func (c *Collector) calculateUtilization(info map[string]uint64) float64 {
 	}
 
 	used := total - available
-	return (float64(used) / float64(total)) * 100
+	util := (float64(used) / float64(total)) * 100
+	c.Trace(c.Name(), "/proc/meminfo", fmt.Sprintf("MemTotal: %d kB, MemAvailable: %d kB", total, available), util)
+	return util
 }
 
 // calculateSaturation computes memory saturation based on swap usage.
@@ -115,11 +122,13 @@ func (c *Collector) calculateSaturation(info map[string]uint64) (float64, string
 	swapFree := info["SwapFree"]
 
 	if swapTotal == 0 {
+		c.Trace(c.Name(), "/proc/meminfo", "SwapTotal: 0 kB", 0)
 		return 0, "0 (no swap)"
 	}
 
 	swapUsed := swapTotal - swapFree
 	swapPercent := (float64(swapUsed) / float64(swapTotal)) * 100
+	c.Trace(c.Name(), "/proc/meminfo", fmt.Sprintf("SwapTotal: %d kB, SwapFree: %d kB", swapTotal, swapFree), swapPercent)
 
 	return swapPercent, fmt.Sprintf("%.1f%% swap", swapPercent)
 }
@@ -142,5 +151,18 @@ func (c *Collector) getErrors() int64 {
 		}
 	}
 
+	c.Trace(c.Name(), "/var/log/kern.log", fmt.Sprintf("%d OOM lines", count), float64(count))
 	return count
 }
+
+// Preflight reports whether the kernel log used for OOM events is readable.
+func (c *Collector) Preflight() []use.Access {
+	ok, reason := use.ReadAccess("/var/log/kern.log")
+	return []use.Access{{
+		Collector:  c.Name(),
+		Source:     "/var/log/kern.log",
+		Accessible: ok,
+		Reason:     reason,
+		Impact:     "OOM kill events always reported as 0",
+	}}
+}
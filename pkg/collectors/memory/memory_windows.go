@@ -0,0 +1,92 @@
+//go:build windows
+
+package memory
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+var (
+	modkernel32              = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalMemoryStatusEx = modkernel32.NewProc("GlobalMemoryStatusEx")
+)
+
+// memoryStatusEx mirrors MEMORYSTATUSEX. TotalPageFile and AvailPageFile
+// describe the commit limit (physical memory plus page files) and how much
+// of it is left, not the page file alone.
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// Collect gathers memory USE metrics on Windows. Saturation is the commit
+// charge as a share of the commit limit: as it nears 100% the page file is
+// absorbing demand and allocations start to fail. Out-of-memory events are
+// reported as unknown.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0, 3)
+
+	status := memoryStatusEx{Length: uint32(unsafe.Sizeof(memoryStatusEx{}))}
+	if r, _, err := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status))); r == 0 {
+		return nil, fmt.Errorf("GlobalMemoryStatusEx failed: %w", err)
+	}
+	if status.TotalPhys == 0 {
+		return nil, fmt.Errorf("GlobalMemoryStatusEx reported no physical memory")
+	}
+
+	// Utilization
+	util := float64(status.TotalPhys-status.AvailPhys) / float64(status.TotalPhys) * 100
+	c.Trace(c.Name(), "GlobalMemoryStatusEx", fmt.Sprintf("TotalPhys: %d, AvailPhys: %d", status.TotalPhys, status.AvailPhys), util)
+	checks = append(checks, use.Check{
+		Resource:    "Memory",
+		Type:        use.Utilization,
+		Value:       fmt.Sprintf("%.1f%%", util),
+		RawValue:    util,
+		Unit:        use.UnitPercent,
+		ShowsRaw:    true,
+		Status:      thresholds.EvaluateUtilizationFor("Memory", util),
+		Description: "Memory used percentage",
+		Command:     "GlobalMemoryStatusEx",
+	})
+
+	// Saturation (commit charge)
+	if status.TotalPageFile > 0 {
+		commit := float64(status.TotalPageFile-status.AvailPageFile) / float64(status.TotalPageFile) * 100
+		c.Trace(c.Name(), "GlobalMemoryStatusEx", fmt.Sprintf("TotalPageFile: %d, AvailPageFile: %d", status.TotalPageFile, status.AvailPageFile), commit)
+		checks = append(checks, use.Check{
+			Resource:    "Memory",
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.1f%% commit", commit),
+			RawValue:    commit,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "commit",
+			Status:      thresholds.EvaluateSaturationFor("Memory", use.Saturation, commit),
+			Description: "Commit charge of the commit limit (RAM + page file)",
+			Command:     "GlobalMemoryStatusEx",
+		})
+	}
+
+	checks = append(checks, use.Check{
+		Resource:    "Memory",
+		Type:        use.Errors,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: "Out-of-memory events are not collected on Windows",
+		Command:     "-",
+	})
+
+	return checks, nil
+}
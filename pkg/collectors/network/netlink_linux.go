@@ -0,0 +1,127 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// rtnl_link_stats64 field offsets (in uint64 words) for the counters we use.
+const (
+	statRxPackets = iota
+	statTxPackets
+	statRxBytes
+	statTxBytes
+	statRxErrors
+	statTxErrors
+	statRxDropped
+	statTxDropped
+	statMulticast
+	statCollisions
+	statRxLengthErrors
+	statRxOverErrors
+	statRxCRCErrors
+	statRxFrameErrors
+	statRxFIFOErrors
+	statRxMissedErrors
+	statTxAbortedErrors
+	statTxCarrierErrors
+	statTxFIFOErrors
+	statTxHeartbeatErrors
+	statTxWindowErrors
+	statCount // minimum words present on every kernel we support
+)
+
+// readNetlinkStats dumps per-interface counters with RTM_GETLINK. Each
+// interface's IFLA_STATS64 block is a single atomic snapshot, unlike
+// /proc/net/dev which is formatted field by field, and it carries the
+// detailed error counters.
+func readNetlinkStats() (map[string]InterfaceStats, error) {
+	rib, err := syscall.NetlinkRIB(unix.RTM_GETLINK, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("netlink RTM_GETLINK: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(rib)
+	if err != nil {
+		return nil, fmt.Errorf("netlink parse: %w", err)
+	}
+
+	stats := make(map[string]InterfaceStats)
+	for i := range msgs {
+		m := &msgs[i]
+		if m.Header.Type == unix.NLMSG_DONE {
+			break
+		}
+		if m.Header.Type != unix.RTM_NEWLINK || len(m.Data) < unix.SizeofIfInfomsg {
+			continue
+		}
+		attrs, err := syscall.ParseNetlinkRouteAttr(m)
+		if err != nil {
+			continue
+		}
+
+		var name string
+		var words []uint64
+		for _, a := range attrs {
+			switch a.Attr.Type {
+			case unix.IFLA_IFNAME:
+				name = strings.TrimRight(string(a.Value), "\x00")
+			case unix.IFLA_STATS64:
+				words = decodeStats64(a.Value)
+			}
+		}
+		if name == "" || len(words) < statCount {
+			continue
+		}
+
+		stats[name] = InterfaceStats{
+			Name:      name,
+			RxBytes:   words[statRxBytes],
+			TxBytes:   words[statTxBytes],
+			RxPackets: words[statRxPackets],
+			TxPackets: words[statTxPackets],
+			RxErrors:  words[statRxErrors],
+			TxErrors:  words[statTxErrors],
+			RxDropped: words[statRxDropped],
+			TxDropped: words[statTxDropped],
+			Detail: map[string]uint64{
+				"rx_length":    words[statRxLengthErrors],
+				"rx_over":      words[statRxOverErrors],
+				"rx_crc":       words[statRxCRCErrors],
+				"rx_frame":     words[statRxFrameErrors],
+				"rx_fifo":      words[statRxFIFOErrors],
+				"rx_missed":    words[statRxMissedErrors],
+				"tx_aborted":   words[statTxAbortedErrors],
+				"tx_carrier":   words[statTxCarrierErrors],
+				"tx_fifo":      words[statTxFIFOErrors],
+				"tx_heartbeat": words[statTxHeartbeatErrors],
+				"tx_window":    words[statTxWindowErrors],
+				"collisions":   words[statCollisions],
+			},
+			Raw: fmt.Sprintf("%s rx_bytes=%d tx_bytes=%d rx_errs=%d tx_errs=%d rx_drop=%d tx_drop=%d",
+				name, words[statRxBytes], words[statTxBytes], words[statRxErrors], words[statTxErrors],
+				words[statRxDropped], words[statTxDropped]),
+		}
+	}
+
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("netlink returned no interface stats")
+	}
+	return stats, nil
+}
+
+// decodeStats64 splits an IFLA_STATS64 payload into host-endian uint64 words.
+func decodeStats64(b []byte) []uint64 {
+	const word = int(unsafe.Sizeof(uint64(0)))
+	words := make([]uint64, len(b)/word)
+	for i := range words {
+		words[i] = binary.NativeEndian.Uint64(b[i*word:])
+	}
+	return words
+}
@@ -1,8 +1,18 @@
 // Package network provides network interface metrics collection for the USE method.
 package network
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
 // Collector gathers network-related USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+	use.CounterHook
+}
 
 // New creates a new network collector.
 func New() *Collector {
@@ -15,3 +25,37 @@ func (c *Collector) Name() string {
 }
 
 // Collect gathers network metrics. Platform-specific implementation in network_linux.go and network_darwin.go.
+
+// dropCheck reports an interface's dropped packets per second over the
+// sample window, or the total since boot in cumulative mode. Any drop in
+// the window is a warning either way.
+func (c *Collector) dropCheck(name string, before, after uint64, elapsed time.Duration, command string) use.Check {
+	var rate float64
+	if after > before {
+		rate = use.PerSecond(after-before, elapsed)
+	}
+	status := use.StatusOK
+	if rate > 0 {
+		status = use.StatusWarning
+	}
+	check := use.Check{
+		Resource:    fmt.Sprintf("Network (%s)", name),
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("%.1f drops/s", rate),
+		RawValue:    rate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Label:       "drops/s",
+		Status:      status,
+		Description: "Dropped packets per second indicate network saturation",
+		Command:     command,
+	}
+	if c.Cumulative() {
+		check.Value = fmt.Sprintf("%d drops", after)
+		check.RawValue = float64(after)
+		check.Unit = use.UnitCount
+		check.Label = "drops"
+		check.Description = "Dropped packets " + use.SinceBoot()
+	}
+	return check
+}
@@ -5,6 +5,7 @@ package network
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -25,24 +26,33 @@ type InterfaceStats struct {
 	TxErrors  uint64
 	RxDropped uint64
 	TxDropped uint64
+	Raw       string // source line, for tracing
 }
 
 // Collect gathers network USE metrics on macOS.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but kills the commands it runs once ctx
+// is done.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0)
 
 	// Get interface stats twice to calculate throughput
-	stats1, err := readNetstatStats()
+	stats1, err := readNetstatStats(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	start := time.Now()
 	time.Sleep(100 * time.Millisecond)
 
-	stats2, err := readNetstatStats()
+	stats2, err := readNetstatStats(ctx)
 	if err != nil {
 		return nil, err
 	}
+	elapsed := time.Since(start)
 
 	for name, s1 := range stats1 {
 		s2, ok := stats2[name]
@@ -61,43 +71,38 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		}
 
 		// Utilization (bytes/sec)
-		rxRate := float64(s2.RxBytes-s1.RxBytes) * 10 // Scale to per-second
-		txRate := float64(s2.TxBytes-s1.TxBytes) * 10
+		rxRate := use.PerSecond(s2.RxBytes-s1.RxBytes, elapsed)
+		txRate := use.PerSecond(s2.TxBytes-s1.TxBytes, elapsed)
 		totalRate := rxRate + txRate
+		c.Trace(c.Name(), "netstat -ib", s1.Raw+" -> "+s2.Raw, totalRate)
 
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Network (%s)", name),
 			Type:        use.Utilization,
 			Value:       formatBytes(totalRate) + "/s",
 			RawValue:    totalRate,
+			Unit:        use.UnitBytesPerSecond,
+			ShowsRaw:    true,
 			Status:      use.StatusOK, // Can't determine % without max bandwidth
 			Description: "Network throughput",
 			Command:     "netstat -ib",
 		})
 
-		// Saturation (dropped packets)
-		drops := s2.RxDropped + s2.TxDropped
-		dropStatus := use.StatusOK
-		if drops > 0 {
-			dropStatus = use.StatusWarning
-		}
-		checks = append(checks, use.Check{
-			Resource:    fmt.Sprintf("Network (%s)", name),
-			Type:        use.Saturation,
-			Value:       fmt.Sprintf("%d drops", drops),
-			RawValue:    float64(drops),
-			Status:      dropStatus,
-			Description: "Dropped packets indicate network saturation",
-			Command:     "netstat -ib",
-		})
+		// Saturation: dropped packets over the sample window
+		drops1, drops2 := s1.RxDropped+s1.TxDropped, s2.RxDropped+s2.TxDropped
+		c.Trace(c.Name(), "netstat -ib", fmt.Sprintf("%s drop=%d -> %d", name, drops1, drops2), float64(drops2)-float64(drops1))
+		checks = append(checks, c.dropCheck(name, drops1, drops2, elapsed, "netstat -ib"))
 
 		// Errors
 		errs := s2.RxErrors + s2.TxErrors
+		c.Trace(c.Name(), "netstat -ib", fmt.Sprintf("%s ierrs=%d oerrs=%d", name, s2.RxErrors, s2.TxErrors), float64(errs))
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Network (%s)", name),
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d", errs),
 			RawValue:    float64(errs),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
 			Status:      use.EvaluateErrors(int64(errs)),
 			Description: "Network interface errors",
 			Command:     "netstat -ib",
@@ -108,8 +113,8 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 }
 
 // readNetstatStats reads network interface statistics from netstat -ib.
-func readNetstatStats() (map[string]InterfaceStats, error) {
-	cmd := exec.Command("netstat", "-ib")
+func readNetstatStats(ctx context.Context) (map[string]InterfaceStats, error) {
+	cmd := exec.CommandContext(ctx, "netstat", "-ib")
 	out, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -139,7 +144,7 @@ func readNetstatStats() (map[string]InterfaceStats, error) {
 			continue
 		}
 
-		s := InterfaceStats{Name: name}
+		s := InterfaceStats{Name: name, Raw: strings.TrimSpace(scanner.Text())}
 		s.RxPackets, _ = strconv.ParseUint(fields[4], 10, 64)
 		s.RxErrors, _ = strconv.ParseUint(fields[5], 10, 64)
 		s.RxBytes, _ = strconv.ParseUint(fields[6], 10, 64)
@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -24,24 +25,33 @@ type InterfaceStats struct {
 	TxErrors  uint64
 	RxDropped uint64
 	TxDropped uint64
+	Detail    map[string]uint64 // error breakdown (netlink only)
+	Raw       string            // source line, for tracing
 }
 
 // Collect gathers network USE metrics on Linux.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0)
 
-	// Get interface stats twice to calculate throughput
-	stats1, err := readNetDevStats()
+	// Get interface stats twice to calculate throughput. Netlink gives atomic
+	// per-interface snapshots; /proc/net/dev is the fallback.
+	read, source, command := readNetlinkStats, "netlink RTM_GETLINK", "ip -s -s link"
+	stats1, err := read()
 	if err != nil {
-		return nil, err
+		read, source, command = readNetDevStats, "/proc/net/dev", "/proc/net/dev"
+		if stats1, err = read(); err != nil {
+			return nil, err
+		}
 	}
 
+	start := time.Now()
 	time.Sleep(100 * time.Millisecond)
 
-	stats2, err := readNetDevStats()
+	stats2, err := read()
 	if err != nil {
 		return nil, err
 	}
+	elapsed := time.Since(start)
 
 	for name, s1 := range stats1 {
 		s2, ok := stats2[name]
@@ -54,47 +64,57 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			continue
 		}
 
-		// Utilization (bytes/sec - we show rate, can't determine % without knowing max)
-		rxRate := float64(s2.RxBytes-s1.RxBytes) * 10 // Scale to per-second
-		txRate := float64(s2.TxBytes-s1.TxBytes) * 10
+		// Utilization: throughput, as a % of link speed when the driver
+		// reports one (virtual interfaces don't)
+		rxRate := use.PerSecond(s2.RxBytes-s1.RxBytes, elapsed)
+		txRate := use.PerSecond(s2.TxBytes-s1.TxBytes, elapsed)
 		totalRate := rxRate + txRate
+		c.Trace(c.Name(), source, s1.Raw+" -> "+s2.Raw, totalRate)
 
-		checks = append(checks, use.Check{
+		util := use.Check{
 			Resource:    fmt.Sprintf("Network (%s)", name),
 			Type:        use.Utilization,
 			Value:       formatBytes(totalRate) + "/s",
 			RawValue:    totalRate,
+			Unit:        use.UnitBytesPerSecond,
+			ShowsRaw:    true,
 			Status:      use.StatusOK, // Can't determine % without max bandwidth
 			Description: "Network throughput",
-			Command:     "/proc/net/dev",
-		})
-
-		// Saturation (dropped packets)
-		drops := s2.RxDropped + s2.TxDropped
-		dropStatus := use.StatusOK
-		if drops > 0 {
-			dropStatus = use.StatusWarning
+			Command:     command,
 		}
-		checks = append(checks, use.Check{
-			Resource:    fmt.Sprintf("Network (%s)", name),
-			Type:        use.Saturation,
-			Value:       fmt.Sprintf("%d drops", drops),
-			RawValue:    float64(drops),
-			Status:      dropStatus,
-			Description: "Dropped packets indicate network saturation",
-			Command:     "/proc/net/dev",
-		})
+		if mbps, ok := linkSpeed(name); ok {
+			// Links are full duplex, so the busier direction is the one
+			// that saturates
+			pct := max(rxRate, txRate) / (mbps * 1e6 / 8) * 100
+			c.Trace(c.Name(), "/sys/class/net/"+name+"/speed", fmt.Sprintf("%.0f Mb/s", mbps), pct)
+			util.Value = fmt.Sprintf("%.1f%% (%s/s of %s)", pct, formatBytes(totalRate), formatLinkSpeed(mbps))
+			util.RawValue = pct
+			util.Unit = use.UnitPercent
+			util.ShowsRaw = false
+			util.Status = thresholds.EvaluateUtilizationFor(util.Resource, pct)
+			util.Description = "Network throughput (busier direction) as % of link speed"
+			util.Command = command + ", /sys/class/net/" + name + "/speed"
+		}
+		checks = append(checks, util)
+
+		// Saturation: dropped packets over the sample window
+		drops1, drops2 := s1.RxDropped+s1.TxDropped, s2.RxDropped+s2.TxDropped
+		c.Trace(c.Name(), source, fmt.Sprintf("%s rx_drop=%d tx_drop=%d -> rx_drop=%d tx_drop=%d", name, s1.RxDropped, s1.TxDropped, s2.RxDropped, s2.TxDropped), float64(drops2)-float64(drops1))
+		checks = append(checks, c.dropCheck(name, drops1, drops2, elapsed, command))
 
 		// Errors
 		errs := s2.RxErrors + s2.TxErrors
+		c.Trace(c.Name(), source, fmt.Sprintf("%s rx_errs=%d tx_errs=%d", name, s2.RxErrors, s2.TxErrors), float64(errs))
 		checks = append(checks, use.Check{
 			Resource:    fmt.Sprintf("Network (%s)", name),
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d", errs),
 			RawValue:    float64(errs),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
 			Status:      use.EvaluateErrors(int64(errs)),
-			Description: "Network interface errors",
-			Command:     "/proc/net/dev",
+			Description: errorDescription(s2.Detail),
+			Command:     command,
 		})
 	}
 
@@ -132,7 +152,7 @@ func readNetDevStats() (map[string]InterfaceStats, error) {
 			continue
 		}
 
-		s := InterfaceStats{Name: name}
+		s := InterfaceStats{Name: name, Raw: strings.TrimSpace(line)}
 		s.RxBytes, _ = strconv.ParseUint(fields[0], 10, 64)
 		s.RxPackets, _ = strconv.ParseUint(fields[1], 10, 64)
 		s.RxErrors, _ = strconv.ParseUint(fields[2], 10, 64)
@@ -148,6 +168,45 @@ func readNetDevStats() (map[string]InterfaceStats, error) {
 	return stats, scanner.Err()
 }
 
+// linkSpeed returns the negotiated link speed in Mb/s from sysfs. It reports
+// false for virtual interfaces, links that are down, and drivers that report
+// -1 or an unknown speed.
+func linkSpeed(name string) (float64, bool) {
+	data, err := os.ReadFile(filepath.Join("/sys/class/net", name, "speed"))
+	if err != nil {
+		return 0, false
+	}
+	mbps, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil || mbps <= 0 {
+		return 0, false
+	}
+	return mbps, true
+}
+
+// formatLinkSpeed formats a link speed in Mb/s, e.g. "1 Gb/s".
+func formatLinkSpeed(mbps float64) string {
+	if mbps >= 1000 {
+		return fmt.Sprintf("%g Gb/s", mbps/1000)
+	}
+	return fmt.Sprintf("%g Mb/s", mbps)
+}
+
+// errorDescription describes interface errors, with the non-zero detailed
+// counters when netlink provided them.
+func errorDescription(detail map[string]uint64) string {
+	var parts []string
+	for _, k := range []string{"rx_crc", "rx_frame", "rx_length", "rx_over", "rx_fifo", "rx_missed",
+		"tx_carrier", "tx_aborted", "tx_fifo", "tx_heartbeat", "tx_window", "collisions"} {
+		if v := detail[k]; v > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", k, v))
+		}
+	}
+	if len(parts) == 0 {
+		return "Network interface errors"
+	}
+	return "Network interface errors (" + strings.Join(parts, ", ") + ")"
+}
+
 // formatBytes formats bytes into human-readable format.
 func formatBytes(b float64) string {
 	const unit = 1024
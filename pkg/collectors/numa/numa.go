@@ -0,0 +1,19 @@
+// Package numa provides per-NUMA-node memory metrics for the USE method.
+package numa
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers per-node memory utilization and remote allocation rates.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new NUMA collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "NUMA"
+}
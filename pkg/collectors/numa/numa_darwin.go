@@ -0,0 +1,10 @@
+//go:build darwin
+
+package numa
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which exposes no NUMA topology.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
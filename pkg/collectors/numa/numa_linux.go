@@ -0,0 +1,189 @@
+//go:build linux
+
+package numa
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+const nodeRoot = "/sys/devices/system/node"
+
+// Collect gathers per-node memory USE metrics on Linux. Aggregate memory
+// utilization can look healthy while one node is exhausted and allocations
+// spill to a remote node. Single-node systems return no checks.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	nodes, err := listNodes()
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) < 2 {
+		return nil, nil
+	}
+
+	before := make(map[string]map[string]uint64, len(nodes))
+	for _, node := range nodes {
+		if stat, err := readNumaStat(node); err == nil {
+			before[node] = stat
+		}
+	}
+	start := time.Now()
+	time.Sleep(100 * time.Millisecond)
+	elapsed := time.Since(start)
+
+	checks := make([]use.Check, 0, 2*len(nodes))
+	for _, node := range nodes {
+		resource := "NUMA (" + node + ")"
+		meminfoPath := filepath.Join(nodeRoot, node, "meminfo")
+		numastatPath := filepath.Join(nodeRoot, node, "numastat")
+
+		// Utilization: memory in use on this node, excluding reclaimable cache
+		info, err := readNodeMemInfo(meminfoPath)
+		if err != nil || info["MemTotal"] == 0 {
+			desc := "MemTotal missing from " + meminfoPath
+			if err != nil {
+				desc = err.Error()
+			}
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        use.Utilization,
+				Value:       "unknown",
+				Status:      use.StatusUnknown,
+				Description: desc,
+				Command:     "numastat -m",
+			})
+		} else {
+			total := info["MemTotal"]
+			available := min(info["MemFree"]+info["FilePages"]+info["SReclaimable"], total)
+			util := float64(total-available) / float64(total) * 100
+			c.Trace(c.Name(), meminfoPath, fmt.Sprintf("MemTotal: %d kB, MemFree: %d kB, FilePages: %d kB, SReclaimable: %d kB",
+				total, info["MemFree"], info["FilePages"], info["SReclaimable"]), util)
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        use.Utilization,
+				Value:       fmt.Sprintf("%.1f%%", util),
+				RawValue:    util,
+				Unit:        use.UnitPercent,
+				ShowsRaw:    true,
+				Status:      thresholds.EvaluateUtilizationFor(resource, util),
+				Description: fmt.Sprintf("Node memory used (%d MB of %d MB)", (total-available)/1024, total/1024),
+				Command:     "numastat -m",
+			})
+		}
+
+		// Saturation: allocations that wanted this node but landed elsewhere
+		stat1, ok := before[node]
+		stat2, err := readNumaStat(node)
+		if !ok || err != nil {
+			desc := "cannot read " + numastatPath
+			if err != nil {
+				desc = err.Error()
+			}
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        use.Saturation,
+				Value:       "unknown",
+				Status:      use.StatusUnknown,
+				Description: desc,
+				Command:     "numastat",
+			})
+			continue
+		}
+
+		hits := stat2["numa_hit"] - stat1["numa_hit"]
+		misses := stat2["numa_miss"] - stat1["numa_miss"]
+		foreign := stat2["numa_foreign"] - stat1["numa_foreign"]
+		var missPct float64
+		if hits+misses > 0 {
+			missPct = float64(misses) / float64(hits+misses) * 100
+		}
+		c.Trace(c.Name(), numastatPath, fmt.Sprintf("numa_hit +%d, numa_miss +%d, numa_foreign +%d", hits, misses, foreign), missPct)
+
+		checks = append(checks, use.Check{
+			Resource: resource,
+			Type:     use.Saturation,
+			Value:    fmt.Sprintf("%.1f%% miss", missPct),
+			RawValue: missPct,
+			Unit:     use.UnitPercent,
+			ShowsRaw: true,
+			Label:    "miss",
+			Status:   thresholds.EvaluateSaturationFor(resource, use.Saturation, missPct),
+			Description: fmt.Sprintf("Allocations placed here though intended for another node (numa_miss %.0f/s, numa_foreign %.0f/s)",
+				use.PerSecond(misses, elapsed), use.PerSecond(foreign, elapsed)),
+			Command: "numastat",
+		})
+	}
+	return checks, nil
+}
+
+// listNodes returns the node directory names (node0, node1, ...) in order.
+func listNodes() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(nodeRoot, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0, len(paths))
+	for _, p := range paths {
+		nodes = append(nodes, filepath.Base(p))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(nodes[i], "node"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(nodes[j], "node"))
+		return a < b
+	})
+	return nodes, nil
+}
+
+// readNodeMemInfo parses a node meminfo file, whose lines look like
+// "Node 0 MemTotal:       16384 kB", into a map of kB values.
+func readNodeMemInfo(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		key := strings.TrimSuffix(fields[2], ":")
+		if v, err := strconv.ParseUint(fields[3], 10, 64); err == nil {
+			info[key] = v
+		}
+	}
+	return info, scanner.Err()
+}
+
+// readNumaStat parses a node's numastat counters.
+func readNumaStat(node string) (map[string]uint64, error) {
+	file, err := os.Open(filepath.Join(nodeRoot, node, "numastat"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			stat[fields[0]] = v
+		}
+	}
+	return stat, scanner.Err()
+}
@@ -0,0 +1,20 @@
+// Package psi provides Linux pressure stall information (PSI) metrics for the
+// USE method.
+package psi
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers CPU, memory and I/O stall percentages from /proc/pressure.
+type Collector struct {
+	use.TraceHook
+}
+
+// New creates a new PSI collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "PSI"
+}
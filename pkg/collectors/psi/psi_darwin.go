@@ -0,0 +1,10 @@
+//go:build darwin
+
+package psi
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which has no pressure stall information.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
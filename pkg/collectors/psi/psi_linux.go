@@ -0,0 +1,119 @@
+//go:build linux
+
+package psi
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// pressure holds one line of a /proc/pressure file ("some" or "full").
+type pressure struct {
+	Avg10, Avg60, Avg300 float64
+}
+
+// Collect gathers PSI saturation on Linux. Unlike load average, PSI measures
+// the share of time tasks actually stalled waiting for a resource.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	checks := make([]use.Check, 0, 3)
+	for _, res := range []string{"cpu", "memory", "io"} {
+		path := "/proc/pressure/" + res
+		resource := "PSI (" + res + ")"
+
+		lines, raw, err := readPressure(path)
+		if err != nil {
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        use.Saturation,
+				Value:       "unknown",
+				Status:      use.StatusUnknown,
+				Description: "PSI unavailable (needs kernel 4.20+ with CONFIG_PSI): " + err.Error(),
+				Command:     "cat " + path,
+			})
+			continue
+		}
+		some, ok := lines["some"]
+		if !ok {
+			checks = append(checks, use.Check{
+				Resource:    resource,
+				Type:        use.Saturation,
+				Value:       "unknown",
+				Status:      use.StatusUnknown,
+				Description: "no \"some\" line in " + path,
+				Command:     "cat " + path,
+			})
+			continue
+		}
+		c.Trace(c.Name(), path, raw, some.Avg10)
+
+		desc := fmt.Sprintf("Time some tasks stalled on %s (avg10; avg60 %.1f%%, avg300 %.1f%%)", res, some.Avg60, some.Avg300)
+		if full, ok := lines["full"]; ok && full.Avg10 > 0 {
+			desc += fmt.Sprintf("; all tasks stalled %.1f%%", full.Avg10)
+		}
+		checks = append(checks, use.Check{
+			Resource:    resource,
+			Type:        use.Saturation,
+			Value:       fmt.Sprintf("%.1f%% stalled", some.Avg10),
+			RawValue:    some.Avg10,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "stalled",
+			Status:      thresholds.EvaluateSaturationFor(resource, use.Saturation, some.Avg10),
+			Description: desc,
+			Command:     "cat " + path,
+		})
+	}
+	return checks, nil
+}
+
+// readPressure parses a /proc/pressure file:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// returning the lines by kind and the raw contents joined for tracing.
+func readPressure(path string) (map[string]pressure, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	lines := make(map[string]pressure)
+	var raw []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		raw = append(raw, line)
+		var p pressure
+		for _, f := range fields[1:] {
+			key, val, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("unexpected %s format: %q", path, line)
+			}
+			switch key {
+			case "avg10":
+				p.Avg10 = v
+			case "avg60":
+				p.Avg60 = v
+			case "avg300":
+				p.Avg300 = v
+			}
+		}
+		lines[fields[0]] = p
+	}
+	return lines, strings.Join(raw, "; "), scanner.Err()
+}
@@ -1,8 +1,13 @@
 // Package scheduler provides scheduler/run-queue metrics collection for the USE method.
 package scheduler
 
+import "github.com/danpilch/umd/pkg/use"
+
 // Collector gathers scheduler-related USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+	use.CounterHook
+}
 
 // New creates a new scheduler collector.
 func New() *Collector {
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
@@ -17,7 +18,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
 	// Utilization: approximate run queue from load average
-	load, err := getLoadAverage()
+	load, err := c.getLoadAverage()
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
@@ -29,26 +30,29 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		})
 	} else {
 		cpuCount := runtime.NumCPU()
-		status := use.StatusOK
-		if load > float64(cpuCount*2) {
-			status = use.StatusWarning
-		}
-		if load > float64(cpuCount*4) {
-			status = use.StatusError
-		}
+		status := thresholds.EvaluateSaturationFor("Scheduler", use.Utilization, load/float64(cpuCount))
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%.2f load (CPUs: %d)", load, cpuCount),
 			RawValue:    load,
+			Unit:        use.UnitRatio,
+			ShowsRaw:    true,
+			Label:       fmt.Sprintf("load (CPUs: %d)", cpuCount),
 			Status:      status,
 			Description: "1-min load average as run queue proxy",
 			Command:     "sysctl vm.loadavg",
 		})
 	}
 
-	// Saturation: context switches from host_statistics
-	csw, err := getContextSwitches()
+	// Saturation: context switch rate, sampled twice
+	csw1, err := c.getContextSwitches()
+	start := time.Now()
+	var csw2 int64
+	if err == nil {
+		time.Sleep(100 * time.Millisecond)
+		csw2, err = c.getContextSwitches()
+	}
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
@@ -59,15 +63,29 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Command:     "sysctl",
 		})
 	} else {
-		checks = append(checks, use.Check{
+		cswRate := use.PerSecond(uint64(csw2-csw1), time.Since(start))
+		// High context switch rates indicate scheduler pressure
+		status := thresholds.EvaluateSaturationFor("Scheduler", use.Saturation, cswRate)
+		sat := use.Check{
 			Resource:    "Scheduler",
 			Type:        use.Saturation,
-			Value:       fmt.Sprintf("%d csw (total)", csw),
-			RawValue:    float64(csw),
-			Status:      use.StatusOK,
-			Description: "Context switches (cumulative)",
-			Command:     "sysctl",
-		})
+			Value:       fmt.Sprintf("%.0f csw/s", cswRate),
+			RawValue:    cswRate,
+			Unit:        use.UnitPerSecond,
+			ShowsRaw:    true,
+			Label:       "csw/s",
+			Status:      status,
+			Description: "Context switches per second",
+			Command:     "sysctl vm.stats.sys.v_swtch",
+		}
+		if c.Cumulative() {
+			sat.Value = fmt.Sprintf("%d csw", csw2)
+			sat.RawValue = float64(csw2)
+			sat.Unit = use.UnitCount
+			sat.Label = "csw"
+			sat.Description = "Context switches " + use.SinceBoot()
+		}
+		checks = append(checks, sat)
 	}
 
 	// Errors: not directly available on macOS, report 0
@@ -76,6 +94,8 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		Type:        use.Errors,
 		Value:       "0",
 		RawValue:    0,
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
 		Status:      use.StatusOK,
 		Description: "No scheduler error metrics available on macOS",
 		Command:     "n/a",
@@ -84,7 +104,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	return checks, nil
 }
 
-func getLoadAverage() (float64, error) {
+func (c *Collector) getLoadAverage() (float64, error) {
 	cmd := exec.Command("sysctl", "-n", "vm.loadavg")
 	out, err := cmd.Output()
 	if err != nil {
@@ -95,14 +115,22 @@ func getLoadAverage() (float64, error) {
 	if len(fields) < 1 {
 		return 0, fmt.Errorf("unexpected sysctl output")
 	}
-	return strconv.ParseFloat(fields[0], 64)
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err == nil {
+		c.Trace(c.Name(), "sysctl vm.loadavg", strings.TrimSpace(string(out)), load)
+	}
+	return load, err
 }
 
-func getContextSwitches() (int64, error) {
+func (c *Collector) getContextSwitches() (int64, error) {
 	cmd := exec.Command("sysctl", "-n", "vm.stats.sys.v_swtch")
 	out, err := cmd.Output()
 	if err != nil {
 		return 0, err
 	}
-	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	csw, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err == nil {
+		c.Trace(c.Name(), "sysctl vm.stats.sys.v_swtch", strings.TrimSpace(string(out)), float64(csw))
+	}
+	return csw, err
 }
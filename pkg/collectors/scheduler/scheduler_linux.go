@@ -19,7 +19,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
 	// Utilization: run queue depth from /proc/stat procs_running
-	runQueue, err := getRunQueueDepth()
+	runQueue, err := c.getRunQueueDepth()
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
@@ -31,18 +31,15 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		})
 	} else {
 		cpuCount := runtime.NumCPU()
-		status := use.StatusOK
-		if runQueue > int64(cpuCount*2) {
-			status = use.StatusWarning
-		}
-		if runQueue > int64(cpuCount*4) {
-			status = use.StatusError
-		}
+		status := thresholds.EvaluateSaturationFor("Scheduler", use.Utilization, float64(runQueue)/float64(cpuCount))
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
 			Type:        use.Utilization,
 			Value:       fmt.Sprintf("%d procs (CPUs: %d)", runQueue, cpuCount),
 			RawValue:    float64(runQueue),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Label:       fmt.Sprintf("procs (CPUs: %d)", cpuCount),
 			Status:      status,
 			Description: "Run queue depth (procs_running)",
 			Command:     "/proc/stat",
@@ -50,7 +47,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	}
 
 	// Saturation: context switches per second
-	csw, err := getContextSwitchRate()
+	csw, cswTotal, err := c.getContextSwitchRate()
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
@@ -61,24 +58,32 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Command:     "/proc/stat",
 		})
 	} else {
-		status := use.StatusOK
 		// High context switch rates indicate scheduler pressure
-		if csw > 100000 {
-			status = use.StatusWarning
-		}
-		checks = append(checks, use.Check{
+		status := thresholds.EvaluateSaturationFor("Scheduler", use.Saturation, csw)
+		sat := use.Check{
 			Resource:    "Scheduler",
 			Type:        use.Saturation,
 			Value:       fmt.Sprintf("%.0f csw/s", csw),
 			RawValue:    csw,
+			Unit:        use.UnitPerSecond,
+			ShowsRaw:    true,
+			Label:       "csw/s",
 			Status:      status,
 			Description: "Context switches per second",
 			Command:     "/proc/stat",
-		})
+		}
+		if c.Cumulative() {
+			sat.Value = fmt.Sprintf("%d csw", cswTotal)
+			sat.RawValue = float64(cswTotal)
+			sat.Unit = use.UnitCount
+			sat.Label = "csw"
+			sat.Description = "Context switches " + use.SinceBoot()
+		}
+		checks = append(checks, sat)
 	}
 
 	// Errors: involuntary context switch ratio from /proc/self/status
-	involCSW, err := getInvoluntaryCSW()
+	involCSW, err := c.getInvoluntaryCSW()
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "Scheduler",
@@ -94,6 +99,8 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d", involCSW),
 			RawValue:    float64(involCSW),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
 			Status:      use.EvaluateErrors(involCSW),
 			Description: "Involuntary context switches (self)",
 			Command:     "/proc/self/status",
@@ -103,7 +110,7 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	return checks, nil
 }
 
-func getRunQueueDepth() (int64, error) {
+func (c *Collector) getRunQueueDepth() (int64, error) {
 	file, err := os.Open("/proc/stat")
 	if err != nil {
 		return 0, err
@@ -118,33 +125,41 @@ func getRunQueueDepth() (int64, error) {
 			if len(fields) < 2 {
 				return 0, fmt.Errorf("unexpected procs_running format")
 			}
-			return strconv.ParseInt(fields[1], 10, 64)
+			depth, err := strconv.ParseInt(fields[1], 10, 64)
+			if err == nil {
+				c.Trace(c.Name(), "/proc/stat", line, float64(depth))
+			}
+			return depth, err
 		}
 	}
 	return 0, fmt.Errorf("procs_running not found in /proc/stat")
 }
 
-func getContextSwitchRate() (float64, error) {
-	csw1, err := readCtxtFromStat()
+// getContextSwitchRate returns context switches per second and the total since boot.
+func (c *Collector) getContextSwitchRate() (float64, uint64, error) {
+	csw1, line1, err := readCtxtFromStat()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
+	start := time.Now()
 	time.Sleep(100 * time.Millisecond)
 
-	csw2, err := readCtxtFromStat()
+	csw2, line2, err := readCtxtFromStat()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	// Scale to per-second (100ms sample * 10)
-	return float64(csw2-csw1) * 10, nil
+	rate := use.PerSecond(csw2-csw1, time.Since(start))
+	c.Trace(c.Name(), "/proc/stat", line1+" -> "+line2, rate)
+	return rate, csw2, nil
 }
 
-func readCtxtFromStat() (uint64, error) {
+// readCtxtFromStat returns the ctxt counter and the raw line it was parsed from.
+func readCtxtFromStat() (uint64, string, error) {
 	file, err := os.Open("/proc/stat")
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer file.Close()
 
@@ -154,15 +169,16 @@ func readCtxtFromStat() (uint64, error) {
 		if strings.HasPrefix(line, "ctxt ") {
 			fields := strings.Fields(line)
 			if len(fields) < 2 {
-				return 0, fmt.Errorf("unexpected ctxt format")
+				return 0, line, fmt.Errorf("unexpected ctxt format")
 			}
-			return strconv.ParseUint(fields[1], 10, 64)
+			ctxt, err := strconv.ParseUint(fields[1], 10, 64)
+			return ctxt, line, err
 		}
 	}
-	return 0, fmt.Errorf("ctxt not found in /proc/stat")
+	return 0, "", fmt.Errorf("ctxt not found in /proc/stat")
 }
 
-func getInvoluntaryCSW() (int64, error) {
+func (c *Collector) getInvoluntaryCSW() (int64, error) {
 	file, err := os.Open("/proc/self/status")
 	if err != nil {
 		return 0, err
@@ -177,7 +193,11 @@ func getInvoluntaryCSW() (int64, error) {
 			if len(fields) < 2 {
 				return 0, fmt.Errorf("unexpected format")
 			}
-			return strconv.ParseInt(fields[1], 10, 64)
+			count, err := strconv.ParseInt(fields[1], 10, 64)
+			if err == nil {
+				c.Trace(c.Name(), "/proc/self/status", line, float64(count))
+			}
+			return count, err
 		}
 	}
 	return 0, nil
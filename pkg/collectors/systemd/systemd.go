@@ -0,0 +1,41 @@
+// Package systemd provides service-level error metrics from failed systemd units.
+package systemd
+
+import "github.com/danpilch/umd/pkg/use"
+
+// defaultCriticalUnits are unit name prefixes whose failure is treated as an
+// error. systemd's own helpers (systemd-*) are left out: many are one-shot
+// units whose failure rarely matters, and critical_units can add them back.
+var defaultCriticalUnits = []string{
+	"dbus",
+	"ssh",
+	"sshd",
+	"network",
+	"NetworkManager",
+	"containerd",
+	"docker",
+	"kubelet",
+	"crond",
+	"cron",
+}
+
+// Collector gathers failed systemd unit metrics.
+type Collector struct {
+	use.TraceHook
+
+	// CriticalUnits lists unit name prefixes whose failure is StatusError
+	// rather than StatusWarning.
+	CriticalUnits []string
+}
+
+// New creates a new systemd collector.
+func New() *Collector {
+	return &Collector{
+		CriticalUnits: defaultCriticalUnits,
+	}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "Systemd"
+}
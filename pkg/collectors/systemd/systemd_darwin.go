@@ -0,0 +1,10 @@
+//go:build darwin
+
+package systemd
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collect returns no checks on macOS, which uses launchd rather than systemd.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return nil, nil
+}
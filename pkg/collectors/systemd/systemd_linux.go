@@ -0,0 +1,100 @@
+//go:build linux
+
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// runtimeDir exists only while systemd is the running init system; this is
+// the check sd_booted(3) makes.
+const runtimeDir = "/run/systemd/system"
+
+// Collect gathers failed systemd unit metrics on Linux.
+// Hosts without systemctl, or where systemd isn't running (most containers),
+// produce no checks.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil, nil
+	}
+	if _, err := os.Stat(runtimeDir); err != nil {
+		return nil, nil
+	}
+
+	failed, err := getFailedUnits()
+	if err != nil {
+		return nil, err
+	}
+	c.Trace(c.Name(), "systemctl --failed", strings.Join(failed, " "), float64(len(failed)))
+
+	status := use.StatusOK
+	if len(failed) > 0 {
+		status = use.StatusWarning
+	}
+	for _, unit := range failed {
+		if c.isCritical(unit) {
+			status = use.StatusError
+			break
+		}
+	}
+
+	desc := "No failed units"
+	if len(failed) > 0 {
+		desc = "Failed units: " + strings.Join(failed, ", ")
+	}
+
+	return []use.Check{{
+		Resource:    "Systemd",
+		Type:        use.Errors,
+		Value:       fmt.Sprintf("%d failed", len(failed)),
+		RawValue:    float64(len(failed)),
+		Unit:        use.UnitCount,
+		ShowsRaw:    true,
+		Label:       "failed",
+		Status:      status,
+		Description: desc,
+		Command:     "systemctl --failed",
+	}}, nil
+}
+
+// getFailedUnits returns the names of units in the failed state.
+func getFailedUnits() ([]string, error) {
+	cmd := exec.Command("systemctl", "--failed", "--no-legend", "--plain")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var units []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		// Older systemctl versions prefix failed units with a bullet
+		name := fields[0]
+		if name == "●" && len(fields) > 1 {
+			name = fields[1]
+		}
+		units = append(units, name)
+	}
+	return units, scanner.Err()
+}
+
+// isCritical returns true if the unit matches a critical unit prefix.
+func (c *Collector) isCritical(unit string) bool {
+	for _, prefix := range c.CriticalUnits {
+		if strings.HasPrefix(unit, prefix) {
+			return true
+		}
+	}
+	return false
+}
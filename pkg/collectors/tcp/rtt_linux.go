@@ -0,0 +1,99 @@
+//go:build linux
+
+package tcp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// wanRTTMs is the median RTT above which retransmits point at the network path.
+	wanRTTMs = 50.0
+	// lanRTTMs is the median RTT below which retransmits point at local congestion.
+	lanRTTMs = 5.0
+)
+
+// rttStats summarizes RTT and congestion window across established sockets.
+type rttStats struct {
+	sockets    int
+	medianRTT  float64 // milliseconds
+	medianCwnd float64 // segments
+}
+
+// getRTTStats samples per-socket RTT and cwnd from `ss -tin`. Returns false
+// when ss is unavailable or there are no established sockets with TCP info.
+func (c *Collector) getRTTStats() (rttStats, bool) {
+	if _, err := exec.LookPath("ss"); err != nil {
+		return rttStats{}, false
+	}
+	out, err := exec.Command("ss", "-tin", "state", "established").Output()
+	if err != nil {
+		return rttStats{}, false
+	}
+
+	var rtts, cwnds []float64
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		var rtt, cwnd float64
+		var hasRTT bool
+		for _, field := range strings.Fields(scanner.Text()) {
+			switch {
+			case strings.HasPrefix(field, "rtt:"):
+				// rtt:<avg>/<var> in ms
+				avg, _, _ := strings.Cut(strings.TrimPrefix(field, "rtt:"), "/")
+				if v, err := strconv.ParseFloat(avg, 64); err == nil {
+					rtt, hasRTT = v, true
+				}
+			case strings.HasPrefix(field, "cwnd:"):
+				cwnd, _ = strconv.ParseFloat(strings.TrimPrefix(field, "cwnd:"), 64)
+			}
+		}
+		if hasRTT {
+			rtts = append(rtts, rtt)
+			cwnds = append(cwnds, cwnd)
+		}
+	}
+	if len(rtts) == 0 {
+		return rttStats{}, false
+	}
+
+	s := rttStats{
+		sockets:    len(rtts),
+		medianRTT:  median(rtts),
+		medianCwnd: median(cwnds),
+	}
+	c.Trace(c.Name(), "ss -tin state established", fmt.Sprintf("%d sockets, median rtt=%.1fms cwnd=%.0f", s.sockets, s.medianRTT, s.medianCwnd), s.medianRTT)
+	return s, true
+}
+
+// describe renders RTT context for the retransmit check, classifying the
+// likely cause when retransmits are elevated.
+func (s rttStats) describe(retransRate float64) string {
+	desc := fmt.Sprintf("median RTT %.1fms, cwnd %.0f over %d sockets", s.medianRTT, s.medianCwnd, s.sockets)
+	if retransRate <= 1.0 {
+		return desc
+	}
+	switch {
+	case s.medianRTT >= wanRTTMs:
+		desc += "; high RTT suggests a lossy network path"
+	case s.medianRTT < lanRTTMs:
+		desc += "; low RTT suggests local congestion (queues, NIC, receiver)"
+	}
+	return desc
+}
+
+func median(vals []float64) float64 {
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return sorted[n/2]
+}
@@ -1,8 +1,21 @@
 // Package tcp provides TCP/IP stack metrics collection for the USE method.
 package tcp
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// sampleInterval is the window between the two counter reads.
+const sampleInterval = 100 * time.Millisecond
+
 // Collector gathers TCP/IP stack USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+	use.CounterHook
+}
 
 // New creates a new TCP collector.
 func New() *Collector {
@@ -13,3 +26,91 @@ func New() *Collector {
 func (c *Collector) Name() string {
 	return "TCP"
 }
+
+// counters is one reading of the TCP stack's cumulative counters.
+type counters struct {
+	outSegs         uint64
+	retransSegs     uint64
+	listenOverflows uint64
+}
+
+// delta returns after-before, or 0 if the counter went backwards (a reset).
+func delta(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// retransPercent returns retransmitted segments as a percentage of segments
+// sent, or 0 when none were sent.
+func retransPercent(retrans, out uint64) float64 {
+	if out == 0 {
+		return 0
+	}
+	return float64(retrans) / float64(out) * 100
+}
+
+// retransCheck reports the retransmit percentage over the sample window, or
+// since boot in cumulative mode. Status is judged on the window either way.
+func (c *Collector) retransCheck(thresholds use.Thresholds, before, after counters, command string) use.Check {
+	pct := retransPercent(delta(before.retransSegs, after.retransSegs), delta(before.outSegs, after.outSegs))
+	check := use.Check{
+		Resource:    "TCP",
+		Type:        use.Utilization,
+		Value:       fmt.Sprintf("%.2f%% retrans", pct),
+		RawValue:    pct,
+		Unit:        use.UnitPercent,
+		ShowsRaw:    true,
+		Label:       "retrans",
+		Status:      thresholds.EvaluateSaturationFor("TCP", use.Utilization, pct),
+		Description: "TCP retransmit rate (RetransSegs/OutSegs)",
+		Command:     command,
+	}
+	if c.Cumulative() {
+		total := retransPercent(after.retransSegs, after.outSegs)
+		check.Value = fmt.Sprintf("%.2f%% retrans", total)
+		check.RawValue = total
+		check.Description = "TCP retransmits (RetransSegs/OutSegs) " + use.SinceBoot()
+	}
+	return check
+}
+
+// overflowCheck reports listen queue overflows per second over the sample
+// window, or the total since boot in cumulative mode. Status is judged on
+// the window either way.
+func (c *Collector) overflowCheck(thresholds use.Thresholds, before, after counters, elapsed time.Duration, desc, command string) use.Check {
+	rate := use.PerSecond(delta(before.listenOverflows, after.listenOverflows), elapsed)
+	check := use.Check{
+		Resource:    "TCP",
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("%.1f overflows/s", rate),
+		RawValue:    rate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Label:       "overflows/s",
+		Status:      thresholds.EvaluateSaturationFor("TCP", use.Saturation, rate),
+		Description: desc + " per second",
+		Command:     command,
+	}
+	if c.Cumulative() {
+		check.Value = fmt.Sprintf("%d overflows", after.listenOverflows)
+		check.RawValue = float64(after.listenOverflows)
+		check.Unit = use.UnitCount
+		check.Label = "overflows"
+		check.Description = desc + " " + use.SinceBoot()
+	}
+	return check
+}
+
+// unknownCheck reports a metric that couldn't be read.
+func unknownCheck(mtype use.MetricType, err error, command string) use.Check {
+	return use.Check{
+		Resource:    "TCP",
+		Type:        mtype,
+		Value:       "unknown",
+		Status:      use.StatusUnknown,
+		Description: err.Error(),
+		Command:     command,
+	}
+}
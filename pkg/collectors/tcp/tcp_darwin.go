@@ -5,96 +5,61 @@ package tcp
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
 
 // Collect gathers TCP/IP stack USE metrics on macOS.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	return c.CollectContext(context.Background(), thresholds)
+}
+
+// CollectContext is like Collect but kills the commands it runs once ctx
+// is done.
+func (c *Collector) CollectContext(ctx context.Context, thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
-	// Utilization: retransmit info from netstat -s
-	retransRate, err := getRetransmitRate()
-	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Utilization,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "netstat -s",
-		})
-	} else {
-		status := use.StatusOK
-		if retransRate > 1.0 {
-			status = use.StatusWarning
-		}
-		if retransRate > 5.0 {
-			status = use.StatusError
+	// Utilization (retransmit %) and saturation (listen queue overflows)
+	// come from netstat -s run twice, so status reflects the current rate
+	// rather than totals since boot
+	before, err := c.readCounters(ctx)
+	if err == nil {
+		start := time.Now()
+		time.Sleep(sampleInterval)
+		var after counters
+		if after, err = c.readCounters(ctx); err == nil {
+			elapsed := time.Since(start)
+			checks = append(checks,
+				c.retransCheck(thresholds, before, after, "netstat -s"),
+				c.overflowCheck(thresholds, before, after, elapsed, "Listen queue overflows", "netstat -s"))
 		}
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Utilization,
-			Value:       fmt.Sprintf("%.2f%% retrans", retransRate),
-			RawValue:    retransRate,
-			Status:      status,
-			Description: "TCP retransmit rate",
-			Command:     "netstat -s",
-		})
 	}
-
-	// Saturation: listen queue overflows from netstat -s
-	overflows, err := getListenOverflows()
 	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Saturation,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "netstat -s",
-		})
-	} else {
-		status := use.StatusOK
-		if overflows > 0 {
-			status = use.StatusWarning
-		}
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Saturation,
-			Value:       fmt.Sprintf("%d overflows", overflows),
-			RawValue:    float64(overflows),
-			Status:      status,
-			Description: "Listen queue overflows",
-			Command:     "netstat -s",
-		})
+		checks = append(checks,
+			unknownCheck(use.Utilization, err, "netstat -s"),
+			unknownCheck(use.Saturation, err, "netstat -s"))
 	}
 
 	// Errors: connection states from netstat -an
-	timeWait, err := getTimeWaitCount()
+	timeWait, err := c.getTimeWaitCount(ctx)
 	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Errors,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "netstat -an",
-		})
+		checks = append(checks, unknownCheck(use.Errors, err, "netstat -an"))
 	} else {
-		status := use.StatusOK
-		if timeWait > 1000 {
-			status = use.StatusWarning
-		}
+		status := thresholds.EvaluateSaturationFor("TCP", use.Errors, float64(timeWait))
 		checks = append(checks, use.Check{
 			Resource:    "TCP",
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d TIME_WAIT", timeWait),
 			RawValue:    float64(timeWait),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Label:       "TIME_WAIT",
 			Status:      status,
 			Description: "Connections in TIME_WAIT state",
 			Command:     "netstat -an",
@@ -104,68 +69,43 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	return checks, nil
 }
 
-func getRetransmitRate() (float64, error) {
-	cmd := exec.Command("netstat", "-s", "-p", "tcp")
+// readCounters reads the segment and listen queue counters from one run of
+// netstat -s.
+func (c *Collector) readCounters(ctx context.Context) (counters, error) {
+	var ctr counters
+	cmd := exec.CommandContext(ctx, "netstat", "-s", "-p", "tcp")
 	out, err := cmd.Output()
 	if err != nil {
-		return 0, err
+		return ctr, err
 	}
 
-	var segsSent, retrans float64
 	scanner := bufio.NewScanner(bytes.NewReader(out))
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if strings.Contains(line, "data packets") && strings.Contains(line, "bytes)") {
-			// "12345 data packets (6789012 bytes)"
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				val, err := strconv.ParseFloat(fields[0], 64)
-				if err == nil {
-					segsSent = val
-				}
-			}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
 		}
-		if strings.Contains(line, "data packet") && strings.Contains(line, "retransmit") {
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				val, err := strconv.ParseFloat(fields[0], 64)
-				if err == nil {
-					retrans = val
-				}
-			}
+		val, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
 		}
-	}
-
-	if segsSent > 0 {
-		return (retrans / segsSent) * 100, nil
-	}
-	return 0, nil
-}
-
-func getListenOverflows() (int64, error) {
-	cmd := exec.Command("netstat", "-s", "-p", "tcp")
-	out, err := cmd.Output()
-	if err != nil {
-		return 0, err
-	}
-
-	var overflows int64
-	scanner := bufio.NewScanner(bytes.NewReader(out))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.Contains(line, "listen queue overflow") {
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				val, _ := strconv.ParseInt(fields[0], 10, 64)
-				overflows += val
-			}
+		switch {
+		case strings.Contains(line, "data packets") && strings.Contains(line, "bytes)"):
+			// "12345 data packets (6789012 bytes)"
+			ctr.outSegs = val
+		case strings.Contains(line, "data packet") && strings.Contains(line, "retransmit"):
+			ctr.retransSegs = val
+		case strings.Contains(line, "listen queue overflow"):
+			ctr.listenOverflows += val
 		}
 	}
-	return overflows, nil
+	c.Trace(c.Name(), "netstat -s -p tcp", fmt.Sprintf("data packets=%d retransmitted=%d listen queue overflows=%d", ctr.outSegs, ctr.retransSegs, ctr.listenOverflows), retransPercent(ctr.retransSegs, ctr.outSegs))
+	return ctr, scanner.Err()
 }
 
-func getTimeWaitCount() (int64, error) {
-	cmd := exec.Command("netstat", "-an", "-p", "tcp")
+func (c *Collector) getTimeWaitCount(ctx context.Context) (int64, error) {
+	cmd := exec.CommandContext(ctx, "netstat", "-an", "-p", "tcp")
 	out, err := cmd.Output()
 	if err != nil {
 		return 0, err
@@ -179,5 +119,6 @@ func getTimeWaitCount() (int64, error) {
 			count++
 		}
 	}
+	c.Trace(c.Name(), "netstat -an -p tcp", fmt.Sprintf("%d TIME_WAIT lines", count), float64(count))
 	return count, nil
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
@@ -16,84 +17,44 @@ import (
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
-	// Utilization: retransmit rate from /proc/net/snmp
-	retransRate, err := getRetransmitRate()
-	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Utilization,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "/proc/net/snmp",
-		})
-	} else {
-		status := use.StatusOK
-		if retransRate > 1.0 {
-			status = use.StatusWarning
-		}
-		if retransRate > 5.0 {
-			status = use.StatusError
+	// Utilization (retransmit %) and saturation (listen queue overflows)
+	// come from counters read twice, so status reflects the current rate
+	// rather than totals since boot
+	before, err := c.readCounters()
+	if err == nil {
+		start := time.Now()
+		time.Sleep(sampleInterval)
+		var after counters
+		if after, err = c.readCounters(); err == nil {
+			elapsed := time.Since(start)
+			retrans := c.retransCheck(thresholds, before, after, "/proc/net/snmp")
+			if rtt, ok := c.getRTTStats(); ok {
+				retrans.Description += "; " + rtt.describe(retrans.RawValue)
+			}
+			checks = append(checks, retrans,
+				c.overflowCheck(thresholds, before, after, elapsed, "Listen queue overflows + drops", "/proc/net/netstat"))
 		}
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Utilization,
-			Value:       fmt.Sprintf("%.2f%% retrans", retransRate),
-			RawValue:    retransRate,
-			Status:      status,
-			Description: "TCP retransmit rate (RetransSegs/OutSegs)",
-			Command:     "/proc/net/snmp",
-		})
 	}
-
-	// Saturation: listen queue overflows from /proc/net/netstat
-	overflows, err := getListenOverflows()
 	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Saturation,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "/proc/net/netstat",
-		})
-	} else {
-		status := use.StatusOK
-		if overflows > 0 {
-			status = use.StatusWarning
-		}
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Saturation,
-			Value:       fmt.Sprintf("%d overflows", overflows),
-			RawValue:    float64(overflows),
-			Status:      status,
-			Description: "Listen queue overflows + drops",
-			Command:     "/proc/net/netstat",
-		})
+		checks = append(checks,
+			unknownCheck(use.Utilization, err, "/proc/net/snmp"),
+			unknownCheck(use.Saturation, err, "/proc/net/netstat"))
 	}
 
 	// Errors: TIME_WAIT count from /proc/net/tcp
-	timeWait, err := getTimeWaitCount()
+	timeWait, err := c.getTimeWaitCount()
 	if err != nil {
-		checks = append(checks, use.Check{
-			Resource:    "TCP",
-			Type:        use.Errors,
-			Value:       "unknown",
-			Status:      use.StatusUnknown,
-			Description: err.Error(),
-			Command:     "/proc/net/tcp",
-		})
+		checks = append(checks, unknownCheck(use.Errors, err, "/proc/net/tcp"))
 	} else {
-		status := use.StatusOK
-		if timeWait > 1000 {
-			status = use.StatusWarning
-		}
+		status := thresholds.EvaluateSaturationFor("TCP", use.Errors, float64(timeWait))
 		checks = append(checks, use.Check{
 			Resource:    "TCP",
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%d TIME_WAIT", timeWait),
 			RawValue:    float64(timeWait),
+			Unit:        use.UnitCount,
+			ShowsRaw:    true,
+			Label:       "TIME_WAIT",
 			Status:      status,
 			Description: "Connections in TIME_WAIT state",
 			Command:     "/proc/net/tcp",
@@ -103,82 +64,67 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	return checks, nil
 }
 
-func getRetransmitRate() (float64, error) {
-	file, err := os.Open("/proc/net/snmp")
+// readCounters reads the segment counters from /proc/net/snmp and the
+// listen queue counters from /proc/net/netstat.
+func (c *Collector) readCounters() (counters, error) {
+	var ctr counters
+	tcp, err := readProcTable("/proc/net/snmp", "Tcp:")
 	if err != nil {
-		return 0, err
+		return ctr, err
 	}
-	defer file.Close()
+	ctr.outSegs = tcp["OutSegs"]
+	ctr.retransSegs = tcp["RetransSegs"]
+	c.Trace(c.Name(), "/proc/net/snmp", fmt.Sprintf("RetransSegs=%d OutSegs=%d", ctr.retransSegs, ctr.outSegs), retransPercent(ctr.retransSegs, ctr.outSegs))
 
-	scanner := bufio.NewScanner(file)
-	var tcpHeaders []string
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "Tcp:") {
-			if tcpHeaders == nil {
-				tcpHeaders = strings.Fields(line)
-			} else {
-				// This is the values line
-				values := strings.Fields(line)
-				outSegsIdx := -1
-				retransIdx := -1
-				for i, h := range tcpHeaders {
-					if h == "OutSegs" {
-						outSegsIdx = i
-					}
-					if h == "RetransSegs" {
-						retransIdx = i
-					}
-				}
-				if outSegsIdx >= 0 && retransIdx >= 0 && outSegsIdx < len(values) && retransIdx < len(values) {
-					outSegs, _ := strconv.ParseFloat(values[outSegsIdx], 64)
-					retrans, _ := strconv.ParseFloat(values[retransIdx], 64)
-					if outSegs > 0 {
-						return (retrans / outSegs) * 100, nil
-					}
-				}
-				return 0, nil
-			}
-		}
+	ext, err := readProcTable("/proc/net/netstat", "TcpExt:")
+	if err != nil {
+		return ctr, err
 	}
-	return 0, fmt.Errorf("TCP stats not found in /proc/net/snmp")
+	ctr.listenOverflows = ext["ListenOverflows"] + ext["ListenDrops"]
+	c.Trace(c.Name(), "/proc/net/netstat", fmt.Sprintf("ListenOverflows=%d ListenDrops=%d", ext["ListenOverflows"], ext["ListenDrops"]), float64(ctr.listenOverflows))
+	return ctr, nil
 }
 
-func getListenOverflows() (int64, error) {
-	file, err := os.Open("/proc/net/netstat")
+// readProcTable parses the header and value lines starting with prefix in
+// a /proc/net/snmp-style file into a map of counter name to value.
+// Non-numeric values (e.g. the signed MaxConn) are skipped.
+func readProcTable(path, prefix string) (map[string]uint64, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
-	var tcpExtHeaders []string
+	var headers []string
 	for scanner.Scan() {
 		line := scanner.Text()
-		if strings.HasPrefix(line, "TcpExt:") {
-			if tcpExtHeaders == nil {
-				tcpExtHeaders = strings.Fields(line)
-			} else {
-				values := strings.Fields(line)
-				var overflows, drops int64
-				for i, h := range tcpExtHeaders {
-					if i < len(values) {
-						if h == "ListenOverflows" {
-							overflows, _ = strconv.ParseInt(values[i], 10, 64)
-						}
-						if h == "ListenDrops" {
-							drops, _ = strconv.ParseInt(values[i], 10, 64)
-						}
-					}
-				}
-				return overflows + drops, nil
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		if headers == nil {
+			headers = strings.Fields(line)
+			continue
+		}
+		values := strings.Fields(line)
+		table := make(map[string]uint64, len(headers))
+		for i, h := range headers[1:] {
+			if i+1 >= len(values) {
+				break
+			}
+			if v, err := strconv.ParseUint(values[i+1], 10, 64); err == nil {
+				table[h] = v
 			}
 		}
+		return table, nil
 	}
-	return 0, fmt.Errorf("TcpExt not found in /proc/net/netstat")
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%s not found in %s", strings.TrimSuffix(prefix, ":"), path)
 }
 
-func getTimeWaitCount() (int64, error) {
+func (c *Collector) getTimeWaitCount() (int64, error) {
 	file, err := os.Open("/proc/net/tcp")
 	if err != nil {
 		return 0, err
@@ -203,6 +149,6 @@ func getTimeWaitCount() (int64, error) {
 			count++
 		}
 	}
+	c.Trace(c.Name(), "/proc/net/tcp", fmt.Sprintf("%d sockets in state 06", count), float64(count))
 	return count, nil
 }
-
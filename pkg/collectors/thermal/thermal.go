@@ -0,0 +1,23 @@
+// Package thermal provides temperature and thermal throttling metrics for the
+// USE method.
+package thermal
+
+import "github.com/danpilch/umd/pkg/use"
+
+// Collector gathers thermal zone temperatures and CPU throttling events.
+// A throttled core reports itself busy while running at a fraction of its
+// clock, so utilization alone understates how little work it is doing.
+type Collector struct {
+	use.TraceHook
+	use.CounterHook
+}
+
+// New creates a new thermal collector.
+func New() *Collector {
+	return &Collector{}
+}
+
+// Name returns the collector name.
+func (c *Collector) Name() string {
+	return "Thermal"
+}
@@ -0,0 +1,116 @@
+//go:build darwin
+
+package thermal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// pressureLevels orders macOS thermal pressure levels; anything above
+// Nominal means the system is shedding performance.
+var pressureLevels = map[string]int{
+	"nominal":  0,
+	"moderate": 1,
+	"heavy":    2,
+	"trapping": 3,
+	"sleeping": 4,
+}
+
+// Collect reports thermal throttling on macOS from powermetrics' thermal
+// pressure level (root), falling back to the CPU speed limit from
+// `pmset -g therm`, which Intel Macs report without root.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	if os.Geteuid() == 0 {
+		if level, ok := c.readPressure(); ok {
+			rank := pressureLevels[strings.ToLower(level)]
+			status := use.StatusOK
+			switch {
+			case rank >= 2:
+				status = use.StatusError
+			case rank == 1:
+				status = use.StatusWarning
+			}
+			return []use.Check{{
+				Resource:    "Thermal (throttle)",
+				Type:        use.Errors,
+				Value:       level,
+				RawValue:    float64(rank),
+				Unit:        use.UnitCount,
+				Status:      status,
+				Description: "Thermal pressure level (Nominal, Moderate, Heavy, Trapping, Sleeping)",
+				Command:     "powermetrics --samplers thermal",
+			}}, nil
+		}
+	}
+
+	limit, ok := c.readSpeedLimit()
+	if !ok {
+		return nil, nil
+	}
+	status := use.StatusOK
+	if limit < 100 {
+		status = use.StatusWarning
+	}
+	return []use.Check{{
+		Resource:    "Thermal (throttle)",
+		Type:        use.Errors,
+		Value:       fmt.Sprintf("%d%% speed limit", limit),
+		RawValue:    float64(limit),
+		Unit:        use.UnitPercent,
+		ShowsRaw:    true,
+		Label:       "speed limit",
+		Status:      status,
+		Description: "CPU speed limit imposed by thermal management (100% = unthrottled)",
+		Command:     "pmset -g therm",
+	}}, nil
+}
+
+// readPressure returns the current thermal pressure level from powermetrics.
+func (c *Collector) readPressure() (string, bool) {
+	out, err := exec.Command("powermetrics", "--samplers", "thermal", "-i", "100", "-n", "1").Output()
+	if err != nil {
+		return "", false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if _, level, ok := strings.Cut(line, "Current pressure level:"); ok {
+			level = strings.TrimSpace(level)
+			c.Trace(c.Name(), "powermetrics --samplers thermal", strings.TrimSpace(line), float64(pressureLevels[strings.ToLower(level)]))
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// readSpeedLimit returns CPU_Speed_Limit from `pmset -g therm`. Apple Silicon
+// Macs print no limit, so ok is false there.
+func (c *Collector) readSpeedLimit() (int, bool) {
+	out, err := exec.Command("pmset", "-g", "therm").Output()
+	if err != nil {
+		return 0, false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, val, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) != "CPU_Speed_Limit" {
+			continue
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			return 0, false
+		}
+		c.Trace(c.Name(), "pmset -g therm", strings.TrimSpace(line), float64(limit))
+		return limit, true
+	}
+	return 0, false
+}
@@ -0,0 +1,186 @@
+//go:build linux
+
+package thermal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// throttleWindow is how long throttle counters are sampled. Throttling that
+// is happening now increments them continuously; longer than the usual 100ms
+// so a slow trickle of events isn't missed.
+const throttleWindow = 250 * time.Millisecond
+
+// zone is one /sys/class/thermal/thermal_zone* reading.
+type zone struct {
+	name      string  // zone type, e.g. "x86_pkg_temp"
+	tempC     float64 // current temperature
+	criticalC float64 // critical trip point, 0 if none
+}
+
+// Collect gathers temperatures and throttle events on Linux.
+func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
+	throttle1, raw1, throttleErr := readThrottleCounts()
+	start := time.Now()
+
+	checks := make([]use.Check, 0)
+	for _, z := range readZones() {
+		resource := fmt.Sprintf("Thermal (%s)", z.name)
+		c.Trace(c.Name(), "/sys/class/thermal", fmt.Sprintf("%s temp=%.1f critical=%.1f", z.name, z.tempC, z.criticalC), z.tempC)
+		check := use.Check{
+			Resource:    resource,
+			Type:        use.Utilization,
+			Value:       fmt.Sprintf("%.1f°C", z.tempC),
+			RawValue:    z.tempC,
+			Unit:        use.UnitCelsius,
+			ShowsRaw:    true,
+			Status:      use.StatusOK, // Can't judge without a critical trip point
+			Description: "Thermal zone temperature",
+			Command:     "/sys/class/thermal",
+		}
+		if z.criticalC > 0 {
+			pct := z.tempC / z.criticalC * 100
+			check.Value = fmt.Sprintf("%.1f°C (%.0f%% of %.0f°C critical)", z.tempC, pct, z.criticalC)
+			check.RawValue = pct
+			check.Unit = use.UnitPercent
+			check.ShowsRaw = false
+			check.Status = thresholds.EvaluateUtilizationFor(resource, pct)
+			check.Description = "Temperature as % of the zone's critical trip point"
+		}
+		checks = append(checks, check)
+	}
+
+	// No thermal_throttle directory (VMs, non-Intel CPUs): skip the check
+	// rather than report a misleading zero
+	if throttleErr != nil {
+		return checks, nil
+	}
+	if elapsed := time.Since(start); elapsed < throttleWindow {
+		time.Sleep(throttleWindow - elapsed)
+	}
+	throttle2, raw2, err := readThrottleCounts()
+	if err != nil {
+		return checks, nil
+	}
+	elapsed := time.Since(start)
+
+	var delta uint64
+	if throttle2 > throttle1 {
+		delta = throttle2 - throttle1
+	}
+	rate := use.PerSecond(delta, elapsed)
+	c.Trace(c.Name(), "/sys/devices/system/cpu/cpu*/thermal_throttle", raw1+" -> "+raw2, rate)
+
+	check := use.Check{
+		Resource:    "Thermal (throttle)",
+		Type:        use.Errors,
+		Value:       fmt.Sprintf("%.1f/s", rate),
+		RawValue:    rate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Status:      use.EvaluateErrors(int64(delta)),
+		Description: fmt.Sprintf("CPU thermal throttle events (%d since boot)", throttle2),
+		Command:     "/sys/devices/system/cpu/cpu*/thermal_throttle",
+	}
+	if c.Cumulative() {
+		check.Value = fmt.Sprintf("%d events", throttle2)
+		check.RawValue = float64(throttle2)
+		check.Unit = use.UnitCount
+		check.Label = "events"
+		check.Description = "CPU thermal throttle events " + use.SinceBoot()
+	}
+	return append(checks, check), nil
+}
+
+// readZones reads every thermal zone's temperature and critical trip point.
+// Zones sharing a type are disambiguated by their zone number.
+func readZones() []zone {
+	dirs, _ := filepath.Glob("/sys/class/thermal/thermal_zone*")
+	var zones []zone
+	var ids []string
+	types := make(map[string]int)
+	for _, dir := range dirs {
+		milli, err := strconv.ParseInt(readString(filepath.Join(dir, "temp")), 10, 64)
+		if err != nil {
+			continue // disabled zones fail to read
+		}
+		id := strings.TrimPrefix(filepath.Base(dir), "thermal_")
+		name := readString(filepath.Join(dir, "type"))
+		if name == "" {
+			name = id
+		}
+		types[name]++
+		zones = append(zones, zone{name: name, tempC: float64(milli) / 1000, criticalC: criticalTrip(dir)})
+		ids = append(ids, id)
+	}
+	for i := range zones {
+		if types[zones[i].name] > 1 {
+			zones[i].name += " " + ids[i]
+		}
+	}
+	return zones
+}
+
+// criticalTrip returns a zone's critical trip point in °C, or 0 if it has none.
+func criticalTrip(dir string) float64 {
+	types, _ := filepath.Glob(filepath.Join(dir, "trip_point_*_type"))
+	for _, t := range types {
+		if readString(t) != "critical" {
+			continue
+		}
+		milli, err := readUint(strings.TrimSuffix(t, "_type") + "_temp")
+		if err == nil && milli > 0 {
+			return float64(milli) / 1000
+		}
+	}
+	return 0
+}
+
+// readThrottleCounts sums core and package throttle counts across CPUs.
+// Package counts are repeated on every CPU of the package, so only the
+// first CPU seen per package is counted.
+func readThrottleCounts() (uint64, string, error) {
+	dirs, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/thermal_throttle")
+	if len(dirs) == 0 {
+		return 0, "", fmt.Errorf("no thermal_throttle counters")
+	}
+	var core, pkg uint64
+	packages := make(map[string]bool)
+	for _, dir := range dirs {
+		if n, err := readUint(filepath.Join(dir, "core_throttle_count")); err == nil {
+			core += n
+		}
+		id := readString(filepath.Join(dir, "..", "topology", "physical_package_id"))
+		if packages[id] {
+			continue
+		}
+		packages[id] = true
+		if n, err := readUint(filepath.Join(dir, "package_throttle_count")); err == nil {
+			pkg += n
+		}
+	}
+	return core + pkg, fmt.Sprintf("core=%d package=%d", core, pkg), nil
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func readString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,118 @@
+//go:build linux
+
+package vmem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// thpSample is khugepaged's cumulative CPU time at a point in time.
+type thpSample struct {
+	ticks uint64
+	at    time.Time
+	ok    bool
+}
+
+// sampleKhugepaged reads khugepaged's utime+stime. ok is false if the thread
+// isn't running (THP disabled or a kernel without it).
+func sampleKhugepaged() thpSample {
+	matches, _ := filepath.Glob("/proc/[0-9]*/comm")
+	for _, comm := range matches {
+		data, err := os.ReadFile(comm)
+		if err != nil || strings.TrimSpace(string(data)) != "khugepaged" {
+			continue
+		}
+		stat, err := os.ReadFile(filepath.Join(filepath.Dir(comm), "stat"))
+		if err != nil {
+			return thpSample{}
+		}
+		// Fields after the parenthesised comm: state is [0], utime [11], stime [12]
+		s := string(stat)
+		fields := strings.Fields(s[strings.LastIndexByte(s, ')')+1:])
+		if len(fields) < 13 {
+			return thpSample{}
+		}
+		utime, _ := strconv.ParseUint(fields[11], 10, 64)
+		stime, _ := strconv.ParseUint(fields[12], 10, 64)
+		return thpSample{ticks: utime + stime, at: time.Now(), ok: true}
+	}
+	return thpSample{}
+}
+
+// thpMode returns the selected value of a transparent_hugepage setting, e.g.
+// "madvise" from "always [madvise] never".
+func thpMode(setting string) string {
+	data, err := os.ReadFile("/sys/kernel/mm/transparent_hugepage/" + setting)
+	if err != nil {
+		return ""
+	}
+	s := string(data)
+	start, end := strings.IndexByte(s, '['), strings.IndexByte(s, ']')
+	if start < 0 || end < start {
+		return strings.TrimSpace(s)
+	}
+	return s[start+1 : end]
+}
+
+// thpCheck reports transparent hugepage defragmentation activity: direct
+// compaction stalls (allocating threads blocked while memory is compacted),
+// khugepaged collapses, and khugepaged's own CPU time. This shows up as
+// unexplained system CPU and tail latency, a known database culprit. Stalls
+// are judged against the "VMem (THP)" saturation limit and khugepaged's CPU
+// percentage against "VMem (khugepaged)"; the worse of the two applies.
+func (c *Collector) thpCheck(thresholds use.Thresholds, vmstat1, vmstat2 map[string]uint64, k1, k2 thpSample, elapsed time.Duration) use.Check {
+	stallRate := use.PerSecond(vmstat2["compact_stall"]-vmstat1["compact_stall"], elapsed)
+	collapseRate := use.PerSecond(vmstat2["thp_collapse_alloc"]-vmstat1["thp_collapse_alloc"], elapsed)
+	c.Trace(c.Name(), "/proc/vmstat", fmt.Sprintf("compact_stall %d -> %d, thp_collapse_alloc %d -> %d",
+		vmstat1["compact_stall"], vmstat2["compact_stall"], vmstat1["thp_collapse_alloc"], vmstat2["thp_collapse_alloc"]), stallRate)
+
+	khugeCPU := -1.0
+	if k1.ok && k2.ok {
+		if elapsed := k2.at.Sub(k1.at).Seconds(); elapsed > 0 {
+			khugeCPU = float64(k2.ticks-k1.ticks) / use.ClockTicks() / elapsed * 100
+			c.Trace(c.Name(), "/proc/[khugepaged]/stat", fmt.Sprintf("utime+stime %d -> %d ticks", k1.ticks, k2.ticks), khugeCPU)
+		}
+	}
+
+	status := thresholds.EvaluateSaturationFor("VMem (THP)", use.Saturation, stallRate)
+	if khugeCPU >= 0 {
+		if s := thresholds.EvaluateSaturationFor("VMem (khugepaged)", use.Saturation, khugeCPU); s.Worse(status) {
+			status = s
+		}
+	}
+
+	value := fmt.Sprintf("stalls: %.0f/s, collapses: %.0f/s", stallRate, collapseRate)
+	if khugeCPU >= 0 {
+		value += fmt.Sprintf(", khugepaged: %.1f%% CPU", khugeCPU)
+	}
+	description := "Compaction stalls + THP collapses; system CPU spent defragmenting memory"
+	if enabled := thpMode("enabled"); enabled != "" {
+		description += fmt.Sprintf(" (THP enabled=%s, defrag=%s)", enabled, thpMode("defrag"))
+	}
+
+	check := use.Check{
+		Resource:    "VMem (THP)",
+		Type:        use.Saturation,
+		Value:       value,
+		RawValue:    stallRate,
+		Unit:        use.UnitPerSecond,
+		Status:      status,
+		Description: description,
+		Command:     "/proc/vmstat",
+	}
+	if c.Cumulative() {
+		stalls, collapses := vmstat2["compact_stall"], vmstat2["thp_collapse_alloc"]
+		check.Value = fmt.Sprintf("stalls: %d, collapses: %d", stalls, collapses)
+		check.RawValue = float64(stalls)
+		check.Unit = use.UnitCount
+		check.Description = "Compaction stalls + THP collapses " + use.SinceBoot()
+	}
+	return check
+}
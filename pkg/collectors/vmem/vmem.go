@@ -1,8 +1,13 @@
 // Package vmem provides virtual memory metrics collection for the USE method.
 package vmem
 
+import "github.com/danpilch/umd/pkg/use"
+
 // Collector gathers virtual memory USE metrics.
-type Collector struct{}
+type Collector struct {
+	use.TraceHook
+	use.CounterHook
+}
 
 // New creates a new virtual memory collector.
 func New() *Collector {
@@ -5,76 +5,122 @@ package vmem
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danpilch/umd/pkg/use"
 )
 
-// Collect gathers virtual memory USE metrics on macOS.
+// Collect gathers virtual memory USE metrics on macOS. vm_stat counters are
+// cumulative, so it samples twice and reports rates unless cumulative mode
+// is selected; status is always judged on the rate.
 func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	checks := make([]use.Check, 0, 3)
 
-	stats, err := readVMStat()
+	stats1, err := readVMStat()
 	if err != nil {
 		return nil, err
 	}
+	start := time.Now()
+	time.Sleep(100 * time.Millisecond)
+	stats2, err := readVMStat()
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
 
-	// Utilization: page faults (major)
-	pageFaults := stats["Page faults"]
-	status := use.StatusOK
-	checks = append(checks, use.Check{
+	rate := func(key string) float64 {
+		return use.PerSecond(stats2[key]-stats1[key], elapsed)
+	}
+
+	// Utilization: page faults
+	faultRate := rate("Page faults")
+	c.Trace(c.Name(), "vm_stat", fmt.Sprintf("Page faults: %d -> %d", stats1["Page faults"], stats2["Page faults"]), faultRate)
+	util := use.Check{
 		Resource:    "VMem",
 		Type:        use.Utilization,
-		Value:       fmt.Sprintf("%d faults (total)", pageFaults),
-		RawValue:    float64(pageFaults),
-		Status:      status,
-		Description: "Page faults (cumulative from vm_stat)",
+		Value:       fmt.Sprintf("%.0f faults/s", faultRate),
+		RawValue:    faultRate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Label:       "faults/s",
+		Status:      use.StatusOK,
+		Description: "Page fault rate (vm_stat)",
 		Command:     "vm_stat",
-	})
+	}
+	if c.Cumulative() {
+		util.Value = fmt.Sprintf("%d faults", stats2["Page faults"])
+		util.RawValue = float64(stats2["Page faults"])
+		util.Unit = use.UnitCount
+		util.Label = "faults"
+		util.Description = "Page faults " + use.SinceBoot()
+	}
+	checks = append(checks, util)
 
 	// Saturation: pageins + pageouts
-	pageins := stats["Pageins"]
-	pageouts := stats["Pageouts"]
-	satTotal := pageins + pageouts
+	pageinRate := rate("Pageins")
+	pageoutRate := rate("Pageouts")
+	c.Trace(c.Name(), "vm_stat", fmt.Sprintf("Pageins: %d -> %d, Pageouts: %d -> %d",
+		stats1["Pageins"], stats2["Pageins"], stats1["Pageouts"], stats2["Pageouts"]), pageinRate+pageoutRate)
 	satStatus := use.StatusOK
-	if pageouts > 0 {
+	if pageoutRate > 0 {
 		satStatus = use.StatusWarning
 	}
-	checks = append(checks, use.Check{
+	sat := use.Check{
 		Resource:    "VMem",
 		Type:        use.Saturation,
-		Value:       fmt.Sprintf("%d pageins, %d pageouts", pageins, pageouts),
-		RawValue:    float64(satTotal),
+		Value:       fmt.Sprintf("pageins: %.0f/s, pageouts: %.0f/s", pageinRate, pageoutRate),
+		RawValue:    pageinRate + pageoutRate,
+		Unit:        use.UnitPerSecond,
 		Status:      satStatus,
 		Description: "Page ins/outs indicate swap activity",
 		Command:     "vm_stat",
-	})
+	}
+	if c.Cumulative() {
+		pageins, pageouts := stats2["Pageins"], stats2["Pageouts"]
+		sat.Value = fmt.Sprintf("%d pageins, %d pageouts", pageins, pageouts)
+		sat.RawValue = float64(pageins + pageouts)
+		sat.Unit = use.UnitCount
+		sat.Description = "Page ins/outs " + use.SinceBoot()
+	}
+	checks = append(checks, sat)
 
 	// Errors: swapouts as a pressure indicator
-	swapouts := stats["Swapouts"]
+	swapoutRate := rate("Swapouts")
+	c.Trace(c.Name(), "vm_stat", fmt.Sprintf("Swapouts: %d -> %d", stats1["Swapouts"], stats2["Swapouts"]), swapoutRate)
 	errStatus := use.StatusOK
-	if swapouts > 0 {
+	if swapoutRate > 0 {
 		errStatus = use.StatusWarning
 	}
-	checks = append(checks, use.Check{
+	errCheck := use.Check{
 		Resource:    "VMem",
 		Type:        use.Errors,
-		Value:       fmt.Sprintf("%d swapouts", swapouts),
-		RawValue:    float64(swapouts),
+		Value:       fmt.Sprintf("%.0f swapouts/s", swapoutRate),
+		RawValue:    swapoutRate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Label:       "swapouts/s",
 		Status:      errStatus,
 		Description: "Swap outs indicate severe memory pressure",
 		Command:     "vm_stat",
-	})
+	}
+	if c.Cumulative() {
+		errCheck.Value = fmt.Sprintf("%d swapouts", stats2["Swapouts"])
+		errCheck.RawValue = float64(stats2["Swapouts"])
+		errCheck.Unit = use.UnitCount
+		errCheck.Label = "swapouts"
+		errCheck.Description = "Swap outs " + use.SinceBoot()
+	}
+	checks = append(checks, errCheck)
 
 	return checks, nil
 }
 
 func readVMStat() (map[string]uint64, error) {
-	cmd := exec.Command("vm_stat")
-	out, err := cmd.Output()
+	out, err := use.CommandOutput(context.Background(), "vm_stat")
 	if err != nil {
 		return nil, err
 	}
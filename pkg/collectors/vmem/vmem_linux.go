@@ -23,64 +23,93 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 		return nil, err
 	}
 
+	khuge1 := sampleKhugepaged()
+
+	start := time.Now()
 	time.Sleep(100 * time.Millisecond)
 
 	vmstat2, err := readVMStat()
 	if err != nil {
 		return nil, err
 	}
+	elapsed := time.Since(start)
+	khuge2 := sampleKhugepaged()
 
 	// Utilization: major page fault rate
 	pgmajfault1 := vmstat1["pgmajfault"]
 	pgmajfault2 := vmstat2["pgmajfault"]
-	faultRate := float64(pgmajfault2-pgmajfault1) * 10 // scale to per-second
+	faultRate := use.PerSecond(pgmajfault2-pgmajfault1, elapsed)
+	c.Trace(c.Name(), "/proc/vmstat", fmt.Sprintf("pgmajfault %d -> %d", pgmajfault1, pgmajfault2), faultRate)
 
-	status := use.StatusOK
-	if faultRate > 10 {
-		status = use.StatusWarning
-	}
-	if faultRate > 100 {
-		status = use.StatusError
-	}
-	checks = append(checks, use.Check{
+	status := thresholds.EvaluateSaturationFor("VMem", use.Utilization, faultRate)
+	util := use.Check{
 		Resource:    "VMem",
 		Type:        use.Utilization,
 		Value:       fmt.Sprintf("%.1f faults/s", faultRate),
 		RawValue:    faultRate,
+		Unit:        use.UnitPerSecond,
+		ShowsRaw:    true,
+		Label:       "faults/s",
 		Status:      status,
 		Description: "Major page fault rate (pgmajfault)",
 		Command:     "/proc/vmstat",
-	})
+	}
+	if c.Cumulative() {
+		util.Value = fmt.Sprintf("%d faults", pgmajfault2)
+		util.RawValue = float64(pgmajfault2)
+		util.Unit = use.UnitCount
+		util.Label = "faults"
+		util.Description = "Major page faults (pgmajfault) " + use.SinceBoot()
+	}
+	checks = append(checks, util)
 
 	// Saturation: swap I/O rate + page scan rate
 	pswpin1 := vmstat1["pswpin"]
 	pswpout1 := vmstat1["pswpout"]
 	pswpin2 := vmstat2["pswpin"]
 	pswpout2 := vmstat2["pswpout"]
-	swapRate := float64((pswpin2-pswpin1)+(pswpout2-pswpout1)) * 10
+	swapRate := use.PerSecond((pswpin2-pswpin1)+(pswpout2-pswpout1), elapsed)
 
 	pgscanKswapd1 := vmstat1["pgscan_kswapd"]
 	pgscanDirect1 := vmstat1["pgscan_direct"]
 	pgscanKswapd2 := vmstat2["pgscan_kswapd"]
 	pgscanDirect2 := vmstat2["pgscan_direct"]
-	scanRate := float64((pgscanKswapd2-pgscanKswapd1)+(pgscanDirect2-pgscanDirect1)) * 10
+	scanRate := use.PerSecond((pgscanKswapd2-pgscanKswapd1)+(pgscanDirect2-pgscanDirect1), elapsed)
+	c.Trace(c.Name(), "/proc/vmstat", fmt.Sprintf("pswpin %d -> %d, pswpout %d -> %d", pswpin1, pswpin2, pswpout1, pswpout2), swapRate)
+	c.Trace(c.Name(), "/proc/vmstat", fmt.Sprintf("pgscan_kswapd %d -> %d, pgscan_direct %d -> %d", pgscanKswapd1, pgscanKswapd2, pgscanDirect1, pgscanDirect2), scanRate)
 
 	satStatus := use.StatusOK
 	if swapRate > 0 || scanRate > 0 {
 		satStatus = use.StatusWarning
 	}
-	checks = append(checks, use.Check{
+	sat := use.Check{
 		Resource:    "VMem",
 		Type:        use.Saturation,
 		Value:       fmt.Sprintf("swap: %.0f/s, scan: %.0f/s", swapRate, scanRate),
 		RawValue:    swapRate + scanRate,
+		Unit:        use.UnitPerSecond,
 		Status:      satStatus,
 		Description: "Swap I/O rate + page scan rate",
 		Command:     "/proc/vmstat",
-	})
+	}
+	if c.Cumulative() {
+		swapTotal := pswpin2 + pswpout2
+		scanTotal := pgscanKswapd2 + pgscanDirect2
+		sat.Value = fmt.Sprintf("swap: %d, scan: %d", swapTotal, scanTotal)
+		sat.RawValue = float64(swapTotal + scanTotal)
+		sat.Unit = use.UnitCount
+		sat.Description = "Swap I/O + page scans " + use.SinceBoot()
+	}
+	checks = append(checks, sat)
+
+	// Saturation: dirty-page writeback keeping up with dirtying
+	checks = append(checks, c.writebackCheck(thresholds, vmstat1, vmstat2, elapsed))
+
+	// Saturation: transparent hugepage defrag / compaction stalls
+	checks = append(checks, c.thpCheck(thresholds, vmstat1, vmstat2, khuge1, khuge2, elapsed))
 
 	// Errors: dirty page ratio from /proc/meminfo
-	dirtyRatio, err := getDirtyRatio()
+	dirtyRatio, err := c.getDirtyRatio()
 	if err != nil {
 		checks = append(checks, use.Check{
 			Resource:    "VMem",
@@ -91,18 +120,15 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 			Command:     "/proc/meminfo",
 		})
 	} else {
-		errStatus := use.StatusOK
-		if dirtyRatio > 10 {
-			errStatus = use.StatusWarning
-		}
-		if dirtyRatio > 30 {
-			errStatus = use.StatusError
-		}
+		errStatus := thresholds.EvaluateSaturationFor("VMem", use.Errors, dirtyRatio)
 		checks = append(checks, use.Check{
 			Resource:    "VMem",
 			Type:        use.Errors,
 			Value:       fmt.Sprintf("%.1f%% dirty", dirtyRatio),
 			RawValue:    dirtyRatio,
+			Unit:        use.UnitPercent,
+			ShowsRaw:    true,
+			Label:       "dirty",
 			Status:      errStatus,
 			Description: "Dirty page ratio (Dirty/MemTotal)",
 			Command:     "/proc/meminfo",
@@ -112,6 +138,41 @@ func (c *Collector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	return checks, nil
 }
 
+// writebackCheck compares the rate pages are dirtied against the rate they are
+// written back. Dirty pages accumulating while writeback is in flight leads to
+// write stalls that the static dirty ratio misses. The net dirtying rate is
+// judged against the "VMem (writeback)" saturation limit.
+func (c *Collector) writebackCheck(thresholds use.Thresholds, vmstat1, vmstat2 map[string]uint64, elapsed time.Duration) use.Check {
+	dirtiedRate := use.PerSecond(vmstat2["nr_dirtied"]-vmstat1["nr_dirtied"], elapsed)
+	writtenRate := use.PerSecond(vmstat2["nr_written"]-vmstat1["nr_written"], elapsed)
+	if _, ok := vmstat2["nr_written"]; !ok {
+		// Older kernels: fall back to pgpgout (KB) converted to pages
+		pageKB := float64(os.Getpagesize()) / 1024
+		writtenRate = use.PerSecond(vmstat2["pgpgout"]-vmstat1["pgpgout"], elapsed) / pageKB
+	}
+	netRate := dirtiedRate - writtenRate
+	c.Trace(c.Name(), "/proc/vmstat", fmt.Sprintf("nr_dirtied %d -> %d, nr_written %d -> %d, pgpgout %d -> %d",
+		vmstat1["nr_dirtied"], vmstat2["nr_dirtied"], vmstat1["nr_written"], vmstat2["nr_written"],
+		vmstat1["pgpgout"], vmstat2["pgpgout"]), netRate)
+	dirty := vmstat2["nr_dirty"]
+	writeback := vmstat2["nr_writeback"]
+
+	status := use.StatusOK
+	if writeback > 0 && dirty > vmstat1["nr_dirty"] {
+		status = thresholds.EvaluateSaturationFor("VMem (writeback)", use.Saturation, netRate)
+	}
+	return use.Check{
+		Resource:    "VMem (writeback)",
+		Type:        use.Saturation,
+		Value:       fmt.Sprintf("dirtied: %.0f/s, written: %.0f/s, writeback: %d", dirtiedRate, writtenRate, writeback),
+		RawValue:    netRate,
+		Unit:        use.UnitPerSecond,
+		Status:      status,
+		Description: fmt.Sprintf("Net dirty page accumulation (pages/s); %d dirty, %d under writeback", dirty, writeback),
+		Command:     "/proc/vmstat",
+	}
+}
+
 func readVMStat() (map[string]uint64, error) {
 	file, err := os.Open("/proc/vmstat")
 	if err != nil {
@@ -131,7 +192,7 @@ func readVMStat() (map[string]uint64, error) {
 	return stats, scanner.Err()
 }
 
-func getDirtyRatio() (float64, error) {
+func (c *Collector) getDirtyRatio() (float64, error) {
 	file, err := os.Open("/proc/meminfo")
 	if err != nil {
 		return 0, err
@@ -155,5 +216,7 @@ func getDirtyRatio() (float64, error) {
 	if total == 0 {
 		return 0, fmt.Errorf("MemTotal is 0")
 	}
-	return (float64(dirty) / float64(total)) * 100, nil
+	ratio := (float64(dirty) / float64(total)) * 100
+	c.Trace(c.Name(), "/proc/meminfo", fmt.Sprintf("Dirty: %d kB, MemTotal: %d kB", dirty, total), ratio)
+	return ratio, nil
 }
@@ -0,0 +1,211 @@
+// Package config loads umd thresholds from a YAML or JSON file.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// File is the on-disk config format. In YAML:
+//
+//	warn_util: 80
+//	crit_util: 95
+//	overrides:
+//	  filesystem: {warn_util: 92}   # per-resource utilization
+//	saturation:
+//	  scheduler:
+//	    saturation: {warn: 500000}  # resource -> metric type -> limits
+//	disabled: [tcp, vmem]           # collectors never to run
+type File struct {
+	WarnUtil   float64                                          `json:"warn_util,omitempty"`
+	CritUtil   float64                                          `json:"crit_util,omitempty"`
+	Overrides  map[string]UtilOverride                          `json:"overrides,omitempty"`
+	Saturation map[string]map[use.MetricType]SaturationOverride `json:"saturation,omitempty"`
+
+	// Disabled names collectors to leave out of the registry, matched
+	// case-insensitively, for hosts where they fail or don't apply.
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// UtilOverride sets utilization thresholds for one resource.
+type UtilOverride struct {
+	WarnUtil float64 `json:"warn_util,omitempty"`
+	CritUtil float64 `json:"crit_util,omitempty"`
+}
+
+// SaturationOverride sets saturation limits for one resource and metric
+// type. A field left out keeps the limit already in effect, so
+// {crit: 5} adds a critical level without zeroing the warning one.
+type SaturationOverride struct {
+	Warn *float64 `json:"warn,omitempty"`
+	Crit *float64 `json:"crit,omitempty"`
+}
+
+// DefaultConfigPath returns ~/.umd/config.yaml, or ~/.umd/config.json if
+// only that exists. It returns "" when there is no config file.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range []string{"config.yaml", "config.yml", "config.json"} {
+		path := filepath.Join(home, ".umd", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Load reads thresholds from path, layered over use.DefaultThresholds. An
+// empty path returns the defaults; "-" reads from stdin. Files ending in
+// .json are parsed as JSON, anything else as YAML.
+func Load(path string) (use.Thresholds, error) {
+	f, err := LoadFile(path)
+	if err != nil {
+		return use.Thresholds{}, err
+	}
+	return f.Apply(use.DefaultThresholds()), nil
+}
+
+// LoadFile reads and parses the whole config file, for settings beyond
+// thresholds such as Disabled. An empty path returns an empty File; "-"
+// reads from stdin.
+func LoadFile(path string) (*File, error) {
+	if path == "" {
+		return &File{}, nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = readStdin()
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+
+	name := path
+	if path == "-" {
+		name = "from stdin"
+	}
+	f, err := Parse(data, isJSON(path, data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse config %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// readStdin reads a piped config. An interactive stdin is refused rather
+// than waited on, since `--config -` without a pipe is almost always a
+// mistake and would otherwise hang.
+func readStdin() ([]byte, error) {
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		return nil, fmt.Errorf("stdin is a terminal; pipe the config in, e.g. `gen-config | umd --config -`")
+	}
+	return io.ReadAll(os.Stdin)
+}
+
+// isJSON reports whether config data should be parsed as JSON: by extension
+// for files, by content for stdin.
+func isJSON(path string, data []byte) bool {
+	if path == "-" {
+		return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+	}
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// Parse decodes a config file from JSON or YAML. YAML is converted to JSON
+// first so both share one schema and the same unknown-field checks.
+func Parse(data []byte, asJSON bool) (*File, error) {
+	if !asJSON {
+		var err error
+		if data, err = YAMLToJSON(data); err != nil {
+			return nil, err
+		}
+	}
+	var f File
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Apply layers the file's settings over base.
+func (f *File) Apply(base use.Thresholds) use.Thresholds {
+	t := base
+	if f.WarnUtil != 0 {
+		t.WarnUtil = f.WarnUtil
+	}
+	if f.CritUtil != 0 {
+		t.CritUtil = f.CritUtil
+	}
+
+	if len(f.Overrides) > 0 {
+		overrides := make(map[string]use.ResourceThresholds, len(base.Overrides)+len(f.Overrides))
+		for k, v := range base.Overrides {
+			overrides[k] = v
+		}
+		for k, v := range f.Overrides {
+			overrides[strings.ToLower(k)] = use.ResourceThresholds{WarnUtil: v.WarnUtil, CritUtil: v.CritUtil}
+		}
+		t.Overrides = overrides
+	}
+
+	if len(f.Saturation) > 0 {
+		sat := make(map[string]use.SaturationLimits, len(base.Saturation))
+		for k, v := range base.Saturation {
+			sat[k] = v
+		}
+		t.Saturation = sat
+
+		// Base names first, so an exact resource such as "disk (sda)"
+		// merges over the file's "disk" entry rather than the default
+		for _, exact := range []bool{false, true} {
+			for resource, types := range f.Saturation {
+				if strings.Contains(resource, " (") != exact {
+					continue
+				}
+				for mtype, o := range types {
+					limits, _ := t.SaturationLimitsFor(resource, mtype)
+					if o.Warn != nil {
+						limits.Warn = *o.Warn
+					}
+					if o.Crit != nil {
+						limits.Crit = *o.Crit
+					}
+					sat[use.SaturationKey(resource, mtype)] = limits
+				}
+			}
+		}
+	}
+	return t
+}
+
+// YAMLToJSON converts a YAML document to JSON, so callers can decode it
+// with encoding/json and the struct's json tags. An empty document becomes
+// null.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported YAML: %w", err)
+	}
+	return out, nil
+}
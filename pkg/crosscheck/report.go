@@ -4,21 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/danpilch/umd/pkg/use"
 )
 
 var (
-	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
-	headerStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("62")).Padding(0, 1)
-	validStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	suspectStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("62")).Padding(0, 1)
+	validStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	suspectStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
 	conflictStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	passStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	failStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	passStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	failStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 )
 
 // Report outputs cross-check validation results and sanity checks as a styled table.
@@ -40,6 +42,9 @@ func Report(w io.Writer, validations []ValidationResult, sanity []SanityResult)
 			sourceNames := make([]string, len(v.Sources))
 			for i, s := range v.Sources {
 				sourceNames[i] = fmt.Sprintf("%s=%.1f", s.Name, s.Value)
+				if s.Excluded {
+					sourceNames[i] += " (excluded)"
+				}
 			}
 			var statusStr string
 			switch v.Status {
@@ -93,13 +98,32 @@ func ReportJSON(w io.Writer, validations []ValidationResult, sanity []SanityResu
 	return enc.Encode(output)
 }
 
-// RunCrossChecks performs full cross-validation on collected checks.
-func RunCrossChecks(checks []use.Check) ([]ValidationResult, []SanityResult) {
-	validator := NewValidator()
+// RunCrossChecks performs full cross-validation on collected checks, using
+// validator's tolerance and consensus Mode. A nil validator uses
+// NewValidator's defaults.
+func RunCrossChecks(checks []use.Check, validator *Validator) ([]ValidationResult, []SanityResult) {
+	if validator == nil {
+		validator = NewValidator()
+	}
+
+	// Disk and network sources each sample over a ~1s window; gather them
+	// concurrently so they share it.
+	var diskSources, netSources map[string][]Source
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		diskSources = GetDiskSources()
+	}()
+	go func() {
+		defer wg.Done()
+		netSources = GetNetworkSources()
+	}()
 
 	// Get alternative sources for cross-checking
 	cpuSources := GetCPUSources()
 	memSources := GetMemorySources()
+	wg.Wait()
 
 	var validations []ValidationResult
 
@@ -109,9 +133,29 @@ func RunCrossChecks(checks []use.Check) ([]ValidationResult, []SanityResult) {
 	if len(memSources) > 0 {
 		validations = append(validations, validator.CrossCheck("Memory Utilization", memSources))
 	}
+	validations = append(validations, crossCheckEach(validator, "Disk Utilization", diskSources)...)
+	validations = append(validations, crossCheckEach(validator, "Network Throughput", netSources)...)
 
 	// Run sanity checks on all collected metrics
 	sanity := RunSanityChecks(checks)
 
 	return validations, sanity
 }
+
+// crossCheckEach validates per-device sources in name order, skipping devices
+// with fewer than two sources since there is nothing to compare.
+func crossCheckEach(validator *Validator, metric string, byDevice map[string][]Source) []ValidationResult {
+	devices := make([]string, 0, len(byDevice))
+	for dev, sources := range byDevice {
+		if len(sources) >= 2 {
+			devices = append(devices, dev)
+		}
+	}
+	sort.Strings(devices)
+
+	results := make([]ValidationResult, 0, len(devices))
+	for _, dev := range devices {
+		results = append(results, validator.CrossCheck(fmt.Sprintf("%s (%s)", metric, dev), byDevice[dev]))
+	}
+	return results
+}
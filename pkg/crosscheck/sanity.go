@@ -18,8 +18,9 @@ func RunSanityChecks(checks []use.Check) []SanityResult {
 	var results []SanityResult
 
 	for _, c := range checks {
-		// Utilization must be in [0, 100] when expressed as percentage
-		if c.Type == use.Utilization && c.Status != use.StatusUnknown {
+		// Utilization must be in [0, 100] when expressed as percentage; rates
+		// and counts (e.g. macOS disk KB/s) have no upper bound
+		if c.Type == use.Utilization && c.Unit == use.UnitPercent && c.Status != use.StatusUnknown {
 			if c.RawValue < 0 {
 				results = append(results, SanityResult{
 					Check:   fmt.Sprintf("%s %s", c.Resource, c.Type),
@@ -41,8 +42,8 @@ func RunSanityChecks(checks []use.Check) []SanityResult {
 			}
 		}
 
-		// All values must be non-negative
-		if c.RawValue < 0 && c.Status != use.StatusUnknown {
+		// All values must be non-negative, except temperatures
+		if c.RawValue < 0 && c.Unit != use.UnitCelsius && c.Status != use.StatusUnknown {
 			results = append(results, SanityResult{
 				Check:   fmt.Sprintf("%s %s non-negative", c.Resource, c.Type),
 				Passed:  false,
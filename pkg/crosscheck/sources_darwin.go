@@ -5,6 +5,7 @@ package crosscheck
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -25,20 +26,24 @@ func GetCPUSources() []Source {
 	var sources []Source
 
 	// Source 1: Mach host_processor_info
-	if util, err := machCPUUtilization(); err == nil {
+	if util, raw, err := machCPUUtilization(); err == nil {
 		sources = append(sources, Source{
-			Name:  "host_processor_info",
-			Value: util,
-			Unit:  "%",
+			Name:    "host_processor_info",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  1.0,
 		})
 	}
 
 	// Source 2: top -l1
-	if util, err := topCPUUtilization(); err == nil {
+	if util, raw, err := topCPUUtilization(); err == nil {
 		sources = append(sources, Source{
-			Name:  "top",
-			Value: util,
-			Unit:  "%",
+			Name:    "top",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  0.5,
 		})
 	}
 
@@ -50,27 +55,31 @@ func GetMemorySources() []Source {
 	var sources []Source
 
 	// Source 1: Mach host_statistics64
-	if util, err := machMemoryUtilization(); err == nil {
+	if util, raw, err := machMemoryUtilization(); err == nil {
 		sources = append(sources, Source{
-			Name:  "host_statistics64",
-			Value: util,
-			Unit:  "%",
+			Name:    "host_statistics64",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  1.0,
 		})
 	}
 
 	// Source 2: vm_stat + sysctl hw.memsize
-	if util, err := vmstatMemoryUtilization(); err == nil {
+	if util, raw, err := vmstatMemoryUtilization(); err == nil {
 		sources = append(sources, Source{
-			Name:  "vm_stat+sysctl",
-			Value: util,
-			Unit:  "%",
+			Name:    "vm_stat+sysctl",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  0.5,
 		})
 	}
 
 	return sources
 }
 
-func machCPUUtilization() (float64, error) {
+func machCPUUtilization() (float64, string, error) {
 	var (
 		numCPU     C.natural_t
 		cpuInfo    *C.integer_t
@@ -81,7 +90,7 @@ func machCPUUtilization() (float64, error) {
 	ret := C.host_processor_info(host, C.PROCESSOR_CPU_LOAD_INFO, &numCPU,
 		(*C.processor_info_array_t)(unsafe.Pointer(&cpuInfo)), &numCPUInfo)
 	if ret != C.KERN_SUCCESS {
-		return 0, nil
+		return 0, "", nil
 	}
 	defer C.vm_deallocate(C.mach_task_self_,
 		C.vm_address_t(uintptr(unsafe.Pointer(cpuInfo))),
@@ -97,19 +106,20 @@ func machCPUUtilization() (float64, error) {
 		nice += uint64(cpuLoadInfo[offset+C.CPU_STATE_NICE])
 	}
 
+	raw := fmt.Sprintf("user=%d system=%d idle=%d nice=%d ticks", user, system, idle, nice)
 	total := float64(user + system + idle + nice)
 	if total == 0 {
-		return 0, nil
+		return 0, raw, nil
 	}
 	busy := float64(user + system + nice)
-	return (busy / total) * 100, nil
+	return (busy / total) * 100, raw, nil
 }
 
-func topCPUUtilization() (float64, error) {
+func topCPUUtilization() (float64, string, error) {
 	cmd := exec.Command("top", "-l", "1", "-n", "0", "-s", "0")
 	out, err := cmd.Output()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	scanner := bufio.NewScanner(bytes.NewReader(out))
@@ -123,24 +133,24 @@ func topCPUUtilization() (float64, error) {
 					idleStr := strings.TrimSuffix(parts[i-1], "%")
 					idle, err := strconv.ParseFloat(idleStr, 64)
 					if err != nil {
-						return 0, err
+						return 0, line, err
 					}
-					return 100 - idle, nil
+					return 100 - idle, line, nil
 				}
 			}
 		}
 	}
-	return 0, nil
+	return 0, "", nil
 }
 
-func machMemoryUtilization() (float64, error) {
+func machMemoryUtilization() (float64, string, error) {
 	var totalMem C.uint64_t
 	size := C.size_t(unsafe.Sizeof(totalMem))
 	name := C.CString("hw.memsize")
 	defer C.free(unsafe.Pointer(name))
 
 	if C.sysctlbyname(name, unsafe.Pointer(&totalMem), &size, nil, 0) != 0 {
-		return 0, nil
+		return 0, "", nil
 	}
 
 	var vmStats C.vm_statistics64_data_t
@@ -149,7 +159,7 @@ func machMemoryUtilization() (float64, error) {
 	ret := C.host_statistics64(host, C.HOST_VM_INFO64,
 		(*C.integer_t)(unsafe.Pointer(&vmStats)), &count)
 	if ret != C.KERN_SUCCESS {
-		return 0, nil
+		return 0, "", nil
 	}
 
 	pageSize := uint64(C.vm_kernel_page_size)
@@ -160,26 +170,28 @@ func machMemoryUtilization() (float64, error) {
 
 	freeMem := (freePages + inactivePages + purgeablePages + speculativePages) * pageSize
 	usedMem := uint64(totalMem) - freeMem
-	return (float64(usedMem) / float64(totalMem)) * 100, nil
+	raw := fmt.Sprintf("hw.memsize=%d free=%d inactive=%d purgeable=%d speculative=%d pages",
+		uint64(totalMem), freePages, inactivePages, purgeablePages, speculativePages)
+	return (float64(usedMem) / float64(totalMem)) * 100, raw, nil
 }
 
-func vmstatMemoryUtilization() (float64, error) {
+func vmstatMemoryUtilization() (float64, string, error) {
 	// Get total memory from sysctl
 	cmd := exec.Command("sysctl", "-n", "hw.memsize")
 	out, err := cmd.Output()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	totalMem, err := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	// Get vm_stat output
 	cmd = exec.Command("vm_stat")
 	out, err = cmd.Output()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	// Parse page size and page counts
@@ -218,5 +230,19 @@ func vmstatMemoryUtilization() (float64, error) {
 		stats["Pages purgeable"] + stats["Pages speculative"]
 	freeMem := freePages * pageSize
 	usedMem := totalMem - freeMem
-	return (float64(usedMem) / float64(totalMem)) * 100, nil
+	raw := fmt.Sprintf("hw.memsize=%d free+inactive+purgeable+speculative=%d pages pagesize=%d",
+		totalMem, freePages, pageSize)
+	return (float64(usedMem) / float64(totalMem)) * 100, raw, nil
+}
+
+// GetDiskSources returns nil on macOS: iostat is the only source of disk
+// activity and it reports no busy %, so there is nothing to cross-check.
+func GetDiskSources() map[string][]Source {
+	return nil
+}
+
+// GetNetworkSources returns nil on macOS, where netstat is the only
+// unprivileged source of interface byte counters.
+func GetNetworkSources() map[string][]Source {
+	return nil
 }
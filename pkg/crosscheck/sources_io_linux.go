@@ -0,0 +1,213 @@
+//go:build linux
+
+package crosscheck
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/collectors/disk"
+)
+
+// ioSampleWindow is how long disk and network counters are sampled for, the
+// same one-second interval iostat reports over.
+const ioSampleWindow = time.Second
+
+// GetDiskSources returns per-device disk busy % from /proc/diskstats and,
+// when sysstat is installed, iostat's %util over the same window. The
+// diskstats value is computed independently of the disk collector, so a
+// disagreement with iostat points at the collector's util math.
+func GetDiskSources() map[string][]Source {
+	before, _, err := readDiskTicks()
+	if err != nil {
+		return nil
+	}
+	start := time.Now()
+
+	// iostat reports over the same window; without it, wait out the window
+	iostatRows, err := disk.RunIostat(ioSampleWindow)
+	if err != nil {
+		time.Sleep(time.Until(start.Add(ioSampleWindow)))
+	}
+
+	after, lines, err := readDiskTicks()
+	if err != nil {
+		return nil
+	}
+	elapsedMs := float64(time.Since(start).Milliseconds())
+	if elapsedMs <= 0 {
+		return nil
+	}
+
+	sources := make(map[string][]Source)
+	for dev, t2 := range after {
+		t1, ok := before[dev]
+		if !ok {
+			continue
+		}
+		util := min(float64(t2-t1)/elapsedMs*100, 100)
+		sources[dev] = append(sources[dev], Source{
+			Name:    "/proc/diskstats",
+			Value:   util,
+			Unit:    "%",
+			RawData: fmt.Sprintf("io_ticks %d -> %d over %.0fms: %s", t1, t2, elapsedMs, lines[dev]),
+			Weight:  1.0,
+		})
+		if row, ok := iostatRows[dev]; ok {
+			sources[dev] = append(sources[dev], Source{
+				Name:    "iostat",
+				Value:   row.Util,
+				Unit:    "%",
+				RawData: row.Raw,
+				Weight:  0.5,
+			})
+		}
+	}
+	return sources
+}
+
+// readDiskTicks returns io_ticks (ms spent doing I/O) for whole block
+// devices, skipping partitions, loop and ram devices.
+func readDiskTicks() (map[string]uint64, map[string]string, error) {
+	file, err := os.Open("/proc/diskstats")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	ticks := make(map[string]uint64)
+	lines := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) < 13 {
+			continue
+		}
+		dev := fields[2]
+		if strings.HasPrefix(dev, "loop") || strings.HasPrefix(dev, "ram") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join("/sys/block", dev)); err != nil {
+			continue
+		}
+		if t, err := strconv.ParseUint(fields[12], 10, 64); err == nil {
+			ticks[dev] = t
+			lines[dev] = strings.TrimSpace(line)
+		}
+	}
+	return ticks, lines, scanner.Err()
+}
+
+// GetNetworkSources returns per-interface throughput (rx+tx bytes/s) from
+// /proc/net/dev and, when iproute2 supports JSON, `ip -s link` over the same
+// window. Loopback and idle interfaces are skipped.
+func GetNetworkSources() map[string][]Source {
+	proc1, err := readProcNetDevBytes()
+	if err != nil {
+		return nil
+	}
+	ip1, ipErr := readIPLinkBytes()
+	start := time.Now()
+
+	time.Sleep(ioSampleWindow)
+
+	proc2, err := readProcNetDevBytes()
+	if err != nil {
+		return nil
+	}
+	ip2, err := readIPLinkBytes()
+	if ipErr == nil {
+		ipErr = err
+	}
+	elapsed := time.Since(start).Seconds()
+
+	sources := make(map[string][]Source)
+	for iface, b2 := range proc2 {
+		b1, ok := proc1[iface]
+		if !ok || iface == "lo" || b2 == b1 {
+			continue
+		}
+		sources[iface] = append(sources[iface], Source{
+			Name:    "/proc/net/dev",
+			Value:   float64(b2-b1) / elapsed,
+			Unit:    "B/s",
+			RawData: fmt.Sprintf("rx+tx bytes %d -> %d", b1, b2),
+			Weight:  1.0,
+		})
+		if ipErr == nil {
+			i1, ok1 := ip1[iface]
+			i2, ok2 := ip2[iface]
+			if ok1 && ok2 {
+				sources[iface] = append(sources[iface], Source{
+					Name:    "ip -s link",
+					Value:   float64(i2-i1) / elapsed,
+					Unit:    "B/s",
+					RawData: fmt.Sprintf("stats64 rx+tx bytes %d -> %d", i1, i2),
+					Weight:  1.0,
+				})
+			}
+		}
+	}
+	return sources
+}
+
+// readProcNetDevBytes returns rx+tx bytes per interface from /proc/net/dev.
+func readProcNetDevBytes() (map[string]uint64, error) {
+	file, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	totals := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, data, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(data)
+		if len(fields) < 9 {
+			continue
+		}
+		rx, _ := strconv.ParseUint(fields[0], 10, 64)
+		tx, _ := strconv.ParseUint(fields[8], 10, 64)
+		totals[strings.TrimSpace(name)] = rx + tx
+	}
+	return totals, scanner.Err()
+}
+
+// readIPLinkBytes returns rx+tx bytes per interface from `ip -j -s link`.
+func readIPLinkBytes() (map[string]uint64, error) {
+	out, err := exec.Command("ip", "-j", "-s", "link").Output()
+	if err != nil {
+		return nil, err
+	}
+	var links []struct {
+		IfName  string `json:"ifname"`
+		Stats64 struct {
+			RX struct {
+				Bytes uint64 `json:"bytes"`
+			} `json:"rx"`
+			TX struct {
+				Bytes uint64 `json:"bytes"`
+			} `json:"tx"`
+		} `json:"stats64"`
+	}
+	if err := json.Unmarshal(out, &links); err != nil {
+		return nil, err
+	}
+	totals := make(map[string]uint64, len(links))
+	for _, l := range links {
+		totals[l.IfName] = l.Stats64.RX.Bytes + l.Stats64.TX.Bytes
+	}
+	return totals, nil
+}
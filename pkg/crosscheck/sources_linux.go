@@ -17,30 +17,35 @@ func GetCPUSources() []Source {
 	var sources []Source
 
 	// Source 1: /proc/stat
-	if util, err := procStatCPU(); err == nil {
+	if util, raw, err := procStatCPU(); err == nil {
 		sources = append(sources, Source{
-			Name:  "/proc/stat",
-			Value: util,
-			Unit:  "%",
+			Name:    "/proc/stat",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  1.0,
 		})
 	}
 
 	// Source 2: /proc/loadavg (instantaneous load / CPU count)
-	if load, err := procLoadAvg(); err == nil {
+	if load, raw, err := procLoadAvg(); err == nil {
 		sources = append(sources, Source{
 			Name:    "/proc/loadavg",
 			Value:   load,
 			Unit:    "load/cpu",
-			RawData: "1-min load average normalized to CPU count",
+			RawData: raw,
+			Weight:  0.25,
 		})
 	}
 
 	// Source 3: sysinfo syscall
-	if util, err := sysinfoUptime(); err == nil {
+	if util, raw, err := sysinfoUptime(); err == nil {
 		sources = append(sources, Source{
-			Name:  "sysinfo",
-			Value: util,
-			Unit:  "load/cpu",
+			Name:    "sysinfo",
+			Value:   util,
+			Unit:    "load/cpu",
+			RawData: raw,
+			Weight:  0.25,
 		})
 	}
 
@@ -52,30 +57,34 @@ func GetMemorySources() []Source {
 	var sources []Source
 
 	// Source 1: /proc/meminfo
-	if util, err := procMeminfo(); err == nil {
+	if util, raw, err := procMeminfo(); err == nil {
 		sources = append(sources, Source{
-			Name:  "/proc/meminfo",
-			Value: util,
-			Unit:  "%",
+			Name:    "/proc/meminfo",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  1.0,
 		})
 	}
 
 	// Source 2: sysinfo syscall
-	if util, err := sysinfoMemory(); err == nil {
+	if util, raw, err := sysinfoMemory(); err == nil {
 		sources = append(sources, Source{
-			Name:  "sysinfo",
-			Value: util,
-			Unit:  "%",
+			Name:    "sysinfo",
+			Value:   util,
+			Unit:    "%",
+			RawData: raw,
+			Weight:  1.0,
 		})
 	}
 
 	return sources
 }
 
-func procStatCPU() (float64, error) {
+func procStatCPU() (float64, string, error) {
 	file, err := os.Open("/proc/stat")
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer file.Close()
 
@@ -85,7 +94,7 @@ func procStatCPU() (float64, error) {
 		if strings.HasPrefix(line, "cpu ") {
 			fields := strings.Fields(line)
 			if len(fields) < 8 {
-				return 0, fmt.Errorf("unexpected /proc/stat format")
+				return 0, line, fmt.Errorf("unexpected /proc/stat format")
 			}
 			user, _ := strconv.ParseUint(fields[1], 10, 64)
 			nice, _ := strconv.ParseUint(fields[2], 10, 64)
@@ -101,39 +110,40 @@ func procStatCPU() (float64, error) {
 
 			total := float64(user + nice + system + idle + iowait + irq + softirq + steal)
 			if total == 0 {
-				return 0, nil
+				return 0, line, nil
 			}
 			busy := float64(user + nice + system + irq + softirq + steal)
-			return (busy / total) * 100, nil
+			return (busy / total) * 100, line, nil
 		}
 	}
-	return 0, fmt.Errorf("cpu line not found")
+	return 0, "", fmt.Errorf("cpu line not found")
 }
 
-func procLoadAvg() (float64, error) {
+func procLoadAvg() (float64, string, error) {
 	data, err := os.ReadFile("/proc/loadavg")
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
-	fields := strings.Fields(string(data))
+	raw := strings.TrimSpace(string(data))
+	fields := strings.Fields(raw)
 	if len(fields) < 1 {
-		return 0, fmt.Errorf("unexpected format")
+		return 0, raw, fmt.Errorf("unexpected format")
 	}
 	load, err := strconv.ParseFloat(fields[0], 64)
 	if err != nil {
-		return 0, err
+		return 0, raw, err
 	}
 	cpus := getCPUCount()
 	if cpus == 0 {
 		cpus = 1
 	}
-	return load / float64(cpus) * 100, nil
+	return load / float64(cpus) * 100, fmt.Sprintf("%s (cpus=%d)", raw, cpus), nil
 }
 
-func sysinfoUptime() (float64, error) {
+func sysinfoUptime() (float64, string, error) {
 	var info unix.Sysinfo_t
 	if err := unix.Sysinfo(&info); err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	loads := info.Loads
 	// Loads are scaled by 65536
@@ -142,13 +152,14 @@ func sysinfoUptime() (float64, error) {
 	if cpus == 0 {
 		cpus = 1
 	}
-	return load1 / float64(cpus) * 100, nil
+	raw := fmt.Sprintf("loads[0]=%d (cpus=%d)", loads[0], cpus)
+	return load1 / float64(cpus) * 100, raw, nil
 }
 
-func procMeminfo() (float64, error) {
+func procMeminfo() (float64, string, error) {
 	file, err := os.Open("/proc/meminfo")
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer file.Close()
 
@@ -166,7 +177,7 @@ func procMeminfo() (float64, error) {
 
 	total := info["MemTotal"]
 	if total == 0 {
-		return 0, fmt.Errorf("MemTotal is 0")
+		return 0, "", fmt.Errorf("MemTotal is 0")
 	}
 
 	available, ok := info["MemAvailable"]
@@ -174,13 +185,14 @@ func procMeminfo() (float64, error) {
 		available = info["MemFree"] + info["Buffers"] + info["Cached"]
 	}
 	used := total - available
-	return (float64(used) / float64(total)) * 100, nil
+	raw := fmt.Sprintf("MemTotal=%d kB MemAvailable=%d kB", total, available)
+	return (float64(used) / float64(total)) * 100, raw, nil
 }
 
-func sysinfoMemory() (float64, error) {
+func sysinfoMemory() (float64, string, error) {
 	var info unix.Sysinfo_t
 	if err := unix.Sysinfo(&info); err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	unit := uint64(info.Unit)
 	total := uint64(info.Totalram) * unit
@@ -188,10 +200,11 @@ func sysinfoMemory() (float64, error) {
 	buffers := uint64(info.Bufferram) * unit
 
 	if total == 0 {
-		return 0, fmt.Errorf("total RAM is 0")
+		return 0, "", fmt.Errorf("total RAM is 0")
 	}
 	used := total - free - buffers
-	return (float64(used) / float64(total)) * 100, nil
+	raw := fmt.Sprintf("totalram=%d freeram=%d bufferram=%d bytes", total, free, buffers)
+	return (float64(used) / float64(total)) * 100, raw, nil
 }
 
 func getCPUCount() int {
@@ -0,0 +1,27 @@
+//go:build !linux && !darwin
+
+package crosscheck
+
+// GetCPUSources returns nil: no alternative CPU sources are implemented on
+// this platform, so cross-checking has nothing to compare.
+func GetCPUSources() []Source {
+	return nil
+}
+
+// GetMemorySources returns nil: no alternative memory sources are
+// implemented on this platform.
+func GetMemorySources() []Source {
+	return nil
+}
+
+// GetDiskSources returns nil: no alternative disk sources are implemented
+// on this platform.
+func GetDiskSources() map[string][]Source {
+	return nil
+}
+
+// GetNetworkSources returns nil: no alternative network sources are
+// implemented on this platform.
+func GetNetworkSources() map[string][]Source {
+	return nil
+}
@@ -17,10 +17,13 @@ const (
 
 // Source represents a single metric reading from a specific source.
 type Source struct {
-	Name    string
-	Value   float64
-	Unit    string
-	RawData string
+	Name      string
+	Value     float64
+	Unit      string
+	RawData   string
+	Weight    float64 // trust in weighted consensus modes; 0 excludes the source
+	Deviation float64 // percent deviation from consensus, set by CrossCheck
+	Excluded  bool    // left out of consensus and status, set by CrossCheck
 }
 
 // ValidationResult holds the cross-check outcome for a metric.
@@ -32,10 +35,25 @@ type ValidationResult struct {
 	Status       ValidationStatus
 }
 
+// ConsensusMode selects how CrossCheck combines source values.
+type ConsensusMode string
+
+const (
+	ConsensusMedian         ConsensusMode = ""                // plain median, all sources equal (default)
+	ConsensusWeightedMedian ConsensusMode = "weighted-median" // median by Source.Weight
+	ConsensusWeightedMean   ConsensusMode = "weighted-mean"   // mean by Source.Weight
+)
+
 // Validator cross-checks metrics from multiple sources.
 type Validator struct {
 	SuspectThreshold  float64 // deviation % to mark suspect (default 5%)
 	ConflictThreshold float64 // deviation % to mark conflict (default 20%)
+
+	// Mode selects the consensus calculation. In the weighted modes a source
+	// with Weight 0 is excluded: it doesn't move the consensus or the status,
+	// but its deviation is still reported. If no source has a positive
+	// weight, the plain median is used.
+	Mode ConsensusMode
 }
 
 // NewValidator creates a validator with default thresholds.
@@ -47,7 +65,8 @@ func NewValidator() *Validator {
 }
 
 // CrossCheck validates a metric by comparing values from multiple sources.
-// Returns a ValidationResult with consensus (median) and deviation analysis.
+// Returns a ValidationResult with consensus (median, or weighted per Mode)
+// and deviation analysis.
 func (v *Validator) CrossCheck(metric string, sources []Source) ValidationResult {
 	result := ValidationResult{
 		Metric:  metric,
@@ -64,29 +83,31 @@ func (v *Validator) CrossCheck(metric string, sources []Source) ValidationResult
 		return result
 	}
 
-	// Calculate consensus via median
-	values := make([]float64, len(sources))
-	for i, s := range sources {
-		values[i] = s.Value
-	}
-	sort.Float64s(values)
-
-	if len(values)%2 == 0 {
-		result.Consensus = (values[len(values)/2-1] + values[len(values)/2]) / 2
-	} else {
-		result.Consensus = values[len(values)/2]
+	weighted := v.Mode != ConsensusMedian && hasPositiveWeight(sources)
+	switch {
+	case weighted && v.Mode == ConsensusWeightedMean:
+		result.Consensus = weightedMean(sources)
+	case weighted:
+		result.Consensus = weightedMedian(sources)
+	default:
+		result.Consensus = median(sources)
 	}
 
-	// Calculate max deviation from consensus
-	for _, val := range values {
+	// Calculate per-source and max deviation from consensus
+	result.Sources = make([]Source, len(sources))
+	for i, s := range sources {
+		var dev float64
 		if result.Consensus == 0 {
-			if val != 0 {
-				result.MaxDeviation = 100.0
+			if s.Value != 0 {
+				dev = 100.0
 			}
-			continue
+		} else {
+			dev = math.Abs(s.Value-result.Consensus) / result.Consensus * 100
 		}
-		dev := math.Abs(val-result.Consensus) / result.Consensus * 100
-		if dev > result.MaxDeviation {
+		s.Deviation = dev
+		s.Excluded = weighted && s.Weight <= 0
+		result.Sources[i] = s
+		if !s.Excluded && dev > result.MaxDeviation {
 			result.MaxDeviation = dev
 		}
 	}
@@ -100,3 +121,65 @@ func (v *Validator) CrossCheck(metric string, sources []Source) ValidationResult
 
 	return result
 }
+
+// median returns the plain median of the source values.
+func median(sources []Source) float64 {
+	values := make([]float64, len(sources))
+	for i, s := range sources {
+		values[i] = s.Value
+	}
+	sort.Float64s(values)
+
+	if len(values)%2 == 0 {
+		return (values[len(values)/2-1] + values[len(values)/2]) / 2
+	}
+	return values[len(values)/2]
+}
+
+// weightedMedian returns the value at which half the total weight lies on
+// each side. When the halfway point falls exactly between two values they
+// are averaged, so equal weights give the plain median.
+func weightedMedian(sources []Source) float64 {
+	sorted := make([]Source, 0, len(sources))
+	var total float64
+	for _, s := range sources {
+		if s.Weight > 0 {
+			sorted = append(sorted, s)
+			total += s.Weight
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Value < sorted[j].Value })
+
+	var cum float64
+	for i, s := range sorted {
+		cum += s.Weight
+		if cum > total/2 {
+			return s.Value
+		}
+		if cum == total/2 && i+1 < len(sorted) {
+			return (s.Value + sorted[i+1].Value) / 2
+		}
+	}
+	return sorted[len(sorted)-1].Value
+}
+
+// weightedMean returns the weight-averaged source value.
+func weightedMean(sources []Source) float64 {
+	var sum, total float64
+	for _, s := range sources {
+		if s.Weight > 0 {
+			sum += s.Value * s.Weight
+			total += s.Weight
+		}
+	}
+	return sum / total
+}
+
+func hasPositiveWeight(sources []Source) bool {
+	for _, s := range sources {
+		if s.Weight > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -40,6 +40,28 @@ func (t *TimedCollector) Name() string {
 	return t.inner.Name()
 }
 
+// SetTracer forwards the tracer to the wrapped collector if it supports tracing.
+func (t *TimedCollector) SetTracer(tr use.Tracer) {
+	if tc, ok := t.inner.(use.Traceable); ok {
+		tc.SetTracer(tr)
+	}
+}
+
+// SetCounterMode forwards the counter mode to the wrapped collector if supported.
+func (t *TimedCollector) SetCounterMode(m use.CounterMode) {
+	if cm, ok := t.inner.(use.CounterModal); ok {
+		cm.SetCounterMode(m)
+	}
+}
+
+// Preflight forwards to the wrapped collector if it supports preflight checks.
+func (t *TimedCollector) Preflight() []use.Access {
+	if p, ok := t.inner.(use.Preflighter); ok {
+		return p.Preflight()
+	}
+	return nil
+}
+
 // Collect runs the wrapped collector and records duration.
 func (t *TimedCollector) Collect(thresholds use.Thresholds) ([]use.Check, error) {
 	start := time.Now()
@@ -0,0 +1,130 @@
+// Package statsd pushes checks to a StatsD or DogStatsD agent over UDP, for
+// setups that already run an agent and would rather push than be scraped.
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/output"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// DefaultPrefix starts every metric name unless Emitter.Prefix overrides it.
+const DefaultPrefix = "umd"
+
+// maxPacket keeps datagrams under a typical 1500-byte MTU after IP and UDP
+// headers, so batched gauges aren't fragmented or dropped.
+const maxPacket = 1432
+
+// Emitter sends checks as StatsD gauges.
+type Emitter struct {
+	// Addr is the agent's host:port, e.g. "127.0.0.1:8125".
+	Addr string
+
+	// Prefix starts each metric name; empty uses DefaultPrefix.
+	Prefix string
+
+	// DogStatsD appends resource and type tags to each gauge
+	// (|#resource:cpu,type:utilization) along with Tags.
+	DogStatsD bool
+
+	// Tags are extra DogStatsD tags such as "env:prod". Ignored unless
+	// DogStatsD is set.
+	Tags []string
+}
+
+// New creates a plain StatsD emitter for addr.
+func New(addr string) *Emitter {
+	return &Emitter{Addr: addr}
+}
+
+// Emit sends checks to the StatsD agent at addr without tags.
+func Emit(addr string, checks []use.Check) error {
+	return New(addr).Emit(checks)
+}
+
+// Emit sends each check's raw value as a gauge named
+// <prefix>.<resource>.<type>, e.g. umd.disk_sda.saturation:0.42|g, with
+// resource names sanitized as in the Prometheus format. Checks with unknown
+// status carry no value and are skipped, as is the second of any duplicate
+// resource/type pair. Lines are batched into as few datagrams as fit.
+func (e *Emitter) Emit(checks []use.Check) error {
+	conn, err := net.Dial("udp", e.Addr)
+	if err != nil {
+		return fmt.Errorf("statsd: cannot dial %s: %w", e.Addr, err)
+	}
+	defer conn.Close()
+
+	var packet bytes.Buffer
+	flush := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		_, err := conn.Write(packet.Bytes())
+		packet.Reset()
+		if err != nil {
+			return fmt.Errorf("statsd: send to %s: %w", e.Addr, err)
+		}
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			continue
+		}
+		label := output.MetricLabel(c.Resource)
+		key := label + "|" + string(c.Type) + "|" + c.Kind
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		for _, line := range e.lines(label, c) {
+			if packet.Len() > 0 && packet.Len()+1+len(line) > maxPacket {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if packet.Len() > 0 {
+				packet.WriteByte('\n')
+			}
+			packet.WriteString(line)
+		}
+	}
+	return flush()
+}
+
+// lines formats one check as gauge lines. StatsD reads a leading sign as a
+// relative change, so a negative value is sent as a reset to 0 followed by
+// the decrement.
+func (e *Emitter) lines(label string, c use.Check) []string {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	name := prefix + "." + label + "." + string(c.Type)
+	if c.Kind != "" {
+		name += "." + c.Kind
+	}
+
+	suffix := "|g"
+	if e.DogStatsD {
+		tags := []string{"resource:" + label, "type:" + string(c.Type)}
+		if c.Kind != "" {
+			tags = append(tags, "kind:"+c.Kind)
+		}
+		tags = append(tags, e.Tags...)
+		suffix += "|#" + strings.Join(tags, ",")
+	}
+
+	value := strconv.FormatFloat(c.RawValue, 'f', -1, 64)
+	if c.RawValue < 0 {
+		return []string{name + ":0" + suffix, name + ":" + value + suffix}
+	}
+	return []string{name + ":" + value + suffix}
+}
@@ -12,6 +12,7 @@ type CaptureOptions struct {
 	Frequency int    // sampling frequency in Hz
 	PID       int    // 0 = system-wide
 	Output    string // output file path
+	OffCPU    bool   // capture where threads block instead of where they run (Linux)
 }
 
 // DefaultCaptureOptions returns sensible defaults.
@@ -23,6 +24,17 @@ func DefaultCaptureOptions() CaptureOptions {
 	}
 }
 
+// SVGOptions returns the SVG defaults for this capture. Off-CPU graphs use
+// the "cold" scheme so they aren't mistaken for on-CPU profiles.
+func (o CaptureOptions) SVGOptions() SVGOptions {
+	svg := DefaultSVGOptions()
+	if o.OffCPU {
+		svg.Title = "Off-CPU Flame Graph"
+		svg.ColorScheme = "cold"
+	}
+	return svg
+}
+
 // CaptureResult holds the result of a capture.
 type CaptureResult struct {
 	CollapsedStacks string // folded stack format
@@ -12,6 +12,10 @@ import (
 )
 
 func platformCapture(ctx context.Context, opts CaptureOptions) (*CaptureResult, error) {
+	if opts.OffCPU {
+		return nil, fmt.Errorf("off-CPU capture is only supported on Linux")
+	}
+
 	durSec := int(opts.Duration.Seconds())
 	if durSec < 1 {
 		durSec = 1
@@ -3,25 +3,32 @@
 package flamegraph
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strconv"
+	"strings"
 	"time"
 )
 
 func platformCapture(ctx context.Context, opts CaptureOptions) (*CaptureResult, error) {
-	// Check if perf is available
-	if _, err := exec.LookPath("perf"); err != nil {
-		return nil, fmt.Errorf("perf not found: install linux-tools-common or equivalent")
-	}
-
 	durSec := int(opts.Duration.Seconds())
 	if durSec < 1 {
 		durSec = 1
 	}
 
+	if opts.OffCPU {
+		return captureOffCPU(ctx, opts, durSec)
+	}
+
+	// Check if perf is available
+	if _, err := exec.LookPath("perf"); err != nil {
+		return nil, fmt.Errorf("perf not found: install linux-tools-common or equivalent")
+	}
+
 	// Run perf record
 	args := []string{
 		"record", "-F", strconv.Itoa(opts.Frequency),
@@ -34,7 +41,11 @@ func platformCapture(ctx context.Context, opts CaptureOptions) (*CaptureResult,
 			"--", "sleep", strconv.Itoa(durSec),
 		}
 	}
+	return capturePerf(ctx, args, durSec)
+}
 
+// capturePerf runs perf record with args, then collapses the perf script output.
+func capturePerf(ctx context.Context, args []string, durSec int) (*CaptureResult, error) {
 	cmd := exec.CommandContext(ctx, "perf", args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
@@ -59,3 +70,69 @@ func platformCapture(ctx context.Context, opts CaptureOptions) (*CaptureResult,
 		Duration:        time.Duration(durSec) * time.Second,
 	}, nil
 }
+
+// captureOffCPU records where threads block. bcc's offcputime weights stacks
+// by microseconds blocked; the perf fallback records sched_switch events, so
+// its stacks are weighted by switch count rather than time.
+func captureOffCPU(ctx context.Context, opts CaptureOptions, durSec int) (*CaptureResult, error) {
+	for _, tool := range []string{"offcputime-bpfcc", "offcputime"} {
+		if _, err := exec.LookPath(tool); err == nil {
+			return captureOffCPUTime(ctx, tool, opts, durSec)
+		}
+	}
+
+	if _, err := exec.LookPath("perf"); err == nil {
+		args := []string{"record", "-e", "sched:sched_switch", "-a", "-g", "--", "sleep", strconv.Itoa(durSec)}
+		if opts.PID > 0 {
+			args = []string{"record", "-e", "sched:sched_switch", "-p", strconv.Itoa(opts.PID), "-g", "--", "sleep", strconv.Itoa(durSec)}
+		}
+		return capturePerf(ctx, args, durSec)
+	}
+
+	return nil, fmt.Errorf("no off-CPU profiling tool found: install bcc-tools (offcputime-bpfcc) or perf")
+}
+
+// captureOffCPUTime runs bcc's offcputime in folded mode, which already emits
+// collapsed stacks.
+func captureOffCPUTime(ctx context.Context, tool string, opts CaptureOptions, durSec int) (*CaptureResult, error) {
+	args := []string{"-f"}
+	if opts.PID > 0 {
+		args = append(args, "-p", strconv.Itoa(opts.PID))
+	}
+	args = append(args, strconv.Itoa(durSec))
+
+	cmd := exec.CommandContext(ctx, tool, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %v (%s)", tool, err, stderr.String())
+	}
+
+	var collapsed bytes.Buffer
+	normalizeFolded(&stdout, &collapsed)
+
+	return &CaptureResult{
+		CollapsedStacks: collapsed.String(),
+		Duration:        time.Duration(durSec) * time.Second,
+	}, nil
+}
+
+// normalizeFolded rewrites "stack count" lines so frames contain no spaces,
+// since the tree builder splits each line at its first space.
+func normalizeFolded(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		i := strings.LastIndex(line, " ")
+		if i <= 0 {
+			continue
+		}
+		stack, count := line[:i], line[i+1:]
+		if _, err := strconv.Atoi(count); err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n", strings.ReplaceAll(stack, " ", "_"), count)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+
+package flamegraph
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// platformCapture fails: capture relies on perf (Linux) or dtrace (macOS).
+// Collapsed stacks or pprof profiles recorded elsewhere can still be
+// rendered with GenerateSVG.
+func platformCapture(ctx context.Context, opts CaptureOptions) (*CaptureResult, error) {
+	return nil, fmt.Errorf("flame graph capture is not supported on %s", runtime.GOOS)
+}
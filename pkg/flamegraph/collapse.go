@@ -109,6 +109,32 @@ func CollapseDtrace(r io.Reader, w io.Writer) {
 	writeCollapsed(w, stacks)
 }
 
+// MergeCollapsed combines folded stack inputs, e.g. captures from several
+// hosts or short windows, summing the counts of identical stacks into one
+// sorted folded output. Lines are read as GenerateSVG reads them: lines
+// without a count are skipped and a zero or unparseable count counts as 1.
+func MergeCollapsed(inputs []io.Reader, w io.Writer) {
+	stacks := make(map[string]int)
+	for _, r := range inputs {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // deep stacks make long lines
+		for scanner.Scan() {
+			stack, countStr, ok := strings.Cut(scanner.Text(), " ")
+			if !ok {
+				continue
+			}
+			count := 0
+			fmt.Sscanf(countStr, "%d", &count)
+			if count == 0 {
+				count = 1
+			}
+			stacks[stack] += count
+		}
+	}
+
+	writeCollapsed(w, stacks)
+}
+
 func isCountLine(s string) bool {
 	for _, c := range s {
 		if c < '0' || c > '9' {
@@ -0,0 +1,102 @@
+package flamegraph
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// FuncDelta describes how a function's self-time changed between two profiles.
+type FuncDelta struct {
+	Function      string  `json:"function"`
+	BeforeSamples int     `json:"before_samples"`
+	AfterSamples  int     `json:"after_samples"`
+	BeforePct     float64 `json:"before_pct"`
+	AfterPct      float64 `json:"after_pct"`
+	DeltaPct      float64 `json:"delta_pct"` // percentage points, after - before
+}
+
+// DiffReport compares two collapsed-stack profiles by function self-time,
+// returning functions sorted by the absolute change in their share of samples.
+// Percentages are used so profiles of different lengths compare fairly.
+func DiffReport(before, after io.Reader) []FuncDelta {
+	beforeRoot, beforeTotal := buildTree(before)
+	afterRoot, afterTotal := buildTree(after)
+
+	beforeSelf := make(map[string]int)
+	afterSelf := make(map[string]int)
+	for _, child := range beforeRoot.children {
+		selfTimes(child, beforeSelf)
+	}
+	for _, child := range afterRoot.children {
+		selfTimes(child, afterSelf)
+	}
+
+	funcs := make(map[string]bool, len(beforeSelf)+len(afterSelf))
+	for fn := range beforeSelf {
+		funcs[fn] = true
+	}
+	for fn := range afterSelf {
+		funcs[fn] = true
+	}
+
+	deltas := make([]FuncDelta, 0, len(funcs))
+	for fn := range funcs {
+		d := FuncDelta{
+			Function:      fn,
+			BeforeSamples: beforeSelf[fn],
+			AfterSamples:  afterSelf[fn],
+			BeforePct:     pct(beforeSelf[fn], beforeTotal),
+			AfterPct:      pct(afterSelf[fn], afterTotal),
+		}
+		d.DeltaPct = d.AfterPct - d.BeforePct
+		deltas = append(deltas, d)
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		ai, aj := math.Abs(deltas[i].DeltaPct), math.Abs(deltas[j].DeltaPct)
+		if ai != aj {
+			return ai > aj
+		}
+		return deltas[i].Function < deltas[j].Function
+	})
+	return deltas
+}
+
+// RenderDiffReport writes the top n function deltas as a plain-text table
+// suitable for pasting into a regression ticket. n <= 0 writes all.
+func RenderDiffReport(w io.Writer, deltas []FuncDelta, n int) {
+	if n <= 0 || n > len(deltas) {
+		n = len(deltas)
+	}
+	fmt.Fprintf(w, "%-50s %10s %10s %8s %8s %9s\n", "FUNCTION", "BEFORE", "AFTER", "BEFORE%", "AFTER%", "DELTA")
+	for _, d := range deltas[:n] {
+		name := d.Function
+		if len(name) > 50 {
+			name = name[:47] + "..."
+		}
+		fmt.Fprintf(w, "%-50s %10d %10d %7.1f%% %7.1f%% %+8.1fpp\n",
+			name, d.BeforeSamples, d.AfterSamples, d.BeforePct, d.AfterPct, d.DeltaPct)
+	}
+}
+
+// selfTimes accumulates per-function self samples (samples where the function
+// is on top of the stack) into self.
+func selfTimes(f *frame, self map[string]int) {
+	childTotal := 0
+	for _, child := range f.children {
+		childTotal += child.value
+		selfTimes(child, self)
+	}
+	if s := f.value - childTotal; s > 0 {
+		self[f.name] += s
+	}
+}
+
+func pct(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
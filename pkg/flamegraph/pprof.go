@@ -0,0 +1,85 @@
+package flamegraph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/pprof/profile"
+)
+
+// CollapsePprof converts a Go pprof profile (as written by runtime/pprof or
+// fetched from net/http/pprof, gzipped or not) to folded stack format. Each
+// stack is weighted by the profile's default sample type if it names one,
+// else by the "samples" count, else by its last sample type, which for a CPU
+// profile is CPU nanoseconds. Inlined calls appear as their own frames.
+func CollapsePprof(r io.Reader, w io.Writer) error {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return fmt.Errorf("pprof: %w", err)
+	}
+
+	valueIdx := pprofValueIndex(p)
+	if valueIdx < 0 {
+		return fmt.Errorf("pprof: profile has no sample types")
+	}
+
+	stacks := make(map[string]int)
+	var frames []string
+	for _, s := range p.Sample {
+		if valueIdx >= len(s.Value) || s.Value[valueIdx] <= 0 {
+			continue
+		}
+		// Locations are leaf first, and within a location inlined callees
+		// precede their caller; folded stacks run root first
+		frames = frames[:0]
+		for _, loc := range s.Location {
+			if len(loc.Line) == 0 {
+				frames = append(frames, fmt.Sprintf("0x%x", loc.Address))
+				continue
+			}
+			for _, line := range loc.Line {
+				frames = append(frames, pprofFunctionName(line.Function))
+			}
+		}
+		if len(frames) == 0 {
+			continue
+		}
+		for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+			frames[i], frames[j] = frames[j], frames[i]
+		}
+		stacks[strings.Join(frames, ";")] += int(s.Value[valueIdx])
+	}
+
+	bw := bufio.NewWriter(w)
+	writeCollapsed(bw, stacks)
+	return bw.Flush()
+}
+
+// pprofValueIndex picks which sample value weights the stacks, or -1 if
+// the profile has no sample types.
+func pprofValueIndex(p *profile.Profile) int {
+	for _, want := range []string{p.DefaultSampleType, "samples"} {
+		if want == "" {
+			continue
+		}
+		for i, t := range p.SampleType {
+			if t.Type == want {
+				return i
+			}
+		}
+	}
+	return len(p.SampleType) - 1
+}
+
+// pprofFunctionName returns a function's name, or its ID if it has none.
+func pprofFunctionName(fn *profile.Function) string {
+	if fn == nil {
+		return "unknown"
+	}
+	if fn.Name != "" {
+		return fn.Name
+	}
+	return fmt.Sprintf("func#%d", fn.ID)
+}
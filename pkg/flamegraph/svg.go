@@ -15,6 +15,21 @@ type SVGOptions struct {
 	Width       int
 	Height      int
 	ColorScheme string // "hot", "cold", "mem"
+	ShowLegend  bool   // color key and % of samples gridlines
+	Inverted    bool   // icicle graph: root at the top, stacks growing down
+
+	// MinWidthPx merges sibling frames narrower than this many pixels into
+	// one "(other)" frame carrying their summed samples, which shrinks deep
+	// profiles full of invisible slivers. Zero renders every frame.
+	MinWidthPx int
+
+	// Interactive embeds a script for click-to-zoom: clicking a frame widens
+	// it to the full chart, rescales its descendants, and offers a
+	// "Reset Zoom" link. Only works where scripts run, e.g. opened in a
+	// browser rather than an <img> tag.
+	// The interactive graph also gets a "Search" link that tints frames
+	// whose name contains a substring and totals their share of samples.
+	Interactive bool
 }
 
 // DefaultSVGOptions returns sensible defaults.
@@ -23,6 +38,7 @@ func DefaultSVGOptions() SVGOptions {
 		Title:       "Flame Graph",
 		Width:       1200,
 		ColorScheme: "hot",
+		ShowLegend:  true,
 	}
 }
 
@@ -40,13 +56,9 @@ func newFrame(name string) *frame {
 	}
 }
 
-// GenerateSVG renders collapsed stacks as an SVG flame graph.
-func GenerateSVG(collapsed io.Reader, svg io.Writer, opts SVGOptions) error {
-	if opts.Width == 0 {
-		opts.Width = 1200
-	}
-
-	// Parse collapsed stacks into tree
+// buildTree parses collapsed stacks into a frame tree, returning the root
+// and the total sample count.
+func buildTree(collapsed io.Reader) (*frame, int) {
 	root := newFrame("root")
 	var totalSamples int
 
@@ -79,7 +91,17 @@ func GenerateSVG(collapsed io.Reader, svg io.Writer, opts SVGOptions) error {
 		}
 		root.value += count
 	}
+	return root, totalSamples
+}
+
+// GenerateSVG renders collapsed stacks as an SVG flame graph.
+func GenerateSVG(collapsed io.Reader, svg io.Writer, opts SVGOptions) error {
+	if opts.Width == 0 {
+		opts.Width = 1200
+	}
 
+	// Parse collapsed stacks into tree
+	root, totalSamples := buildTree(collapsed)
 	if totalSamples == 0 {
 		return fmt.Errorf("no samples found in collapsed stacks")
 	}
@@ -90,7 +112,11 @@ func GenerateSVG(collapsed io.Reader, svg io.Writer, opts SVGOptions) error {
 	maxDepth := getMaxDepth(root, 0)
 	chartHeight := (maxDepth + 2) * frameHeight
 	headerHeight := 40
-	totalHeight := chartHeight + headerHeight + 20
+	legendHeight := 0
+	if opts.ShowLegend {
+		legendHeight = 24
+	}
+	totalHeight := chartHeight + headerHeight + legendHeight + 20
 
 	if opts.Height == 0 {
 		opts.Height = totalHeight
@@ -113,34 +139,82 @@ func GenerateSVG(collapsed io.Reader, svg io.Writer, opts SVGOptions) error {
 		opts.Width/2, html.EscapeString(opts.Title),
 		opts.Width/2, totalSamples)
 
-	// Render frames bottom-up
+	// Render frames bottom-up, or top-down for an icicle graph. Both fill
+	// the same box, leaving a row above it for the gridline labels.
 	margin := 10
 	chartWidth := opts.Width - 2*margin
-	baseY := opts.Height - 20
-	renderFrame(svg, root, margin, baseY, chartWidth, frameHeight, fontSize, totalSamples, 0, opts.ColorScheme)
+	chartBottom := opts.Height - 20 - legendHeight
+	chartTop := chartBottom - (maxDepth+1)*frameHeight
+	rowTop := func(depth int) int { return chartBottom - (depth+1)*frameHeight }
+	if opts.Inverted {
+		chartTop = headerHeight + frameHeight
+		chartBottom = chartTop + (maxDepth+1)*frameHeight
+		rowTop = func(depth int) int { return chartTop + depth*frameHeight }
+	}
+	layout := &svgLayout{
+		rowTop:       rowTop,
+		frameHeight:  frameHeight,
+		totalSamples: totalSamples,
+		scheme:       opts.ColorScheme,
+		minWidth:     opts.MinWidthPx,
+	}
+	if opts.Interactive {
+		layout.ids = new(int)
+	}
+	renderFrame(svg, root, margin, chartWidth, 0, layout)
+
+	if opts.ShowLegend {
+		renderGridlines(svg, margin, chartTop, chartBottom, chartWidth)
+		renderLegend(svg, margin, chartBottom+16, opts.ColorScheme)
+	}
+	if opts.Interactive {
+		renderInteractive(svg, margin, chartWidth, opts.Width, totalSamples)
+	}
 
 	fmt.Fprintln(svg, "</svg>")
 	return nil
 }
 
-func renderFrame(w io.Writer, f *frame, x, baseY, width, frameHeight, fontSize, totalSamples, depth int, scheme string) {
+// svgLayout holds the settings shared by every frame of one graph.
+type svgLayout struct {
+	rowTop       func(depth int) int // top edge of a row; sets the orientation
+	frameHeight  int
+	totalSamples int
+	scheme       string
+	minWidth     int  // siblings narrower than this merge into "(other)"
+	ids          *int // numbers frames for the zoom script; nil for static graphs
+}
+
+// renderFrame draws f and its children.
+func renderFrame(w io.Writer, f *frame, x, width, depth int, l *svgLayout) {
 	if width < 1 || f.value == 0 {
 		return
 	}
 
-	y := baseY - (depth * frameHeight)
+	frameHeight, ids := l.frameHeight, l.ids
+	y := l.rowTop(depth) + frameHeight
 
 	// Get color
-	r, g, b := frameColor(depth, scheme)
+	r, g, b := frameColor(depth, l.scheme)
 
-	// Draw rectangle
-	fmt.Fprintf(w, `<g class="func">
-<rect x="%d" y="%d" width="%d" height="%d" fill="rgb(%d,%d,%d)" rx="1"/>
+	// Draw rectangle. Interactive frames record their original geometry and
+	// full name so the zoom script can rescale and relabel them.
+	if ids != nil {
+		*ids++
+		fmt.Fprintf(w, `<g class="func" id="frame%d" data-depth="%d" data-x="%d" data-width="%d" data-samples="%d" data-name="%s" onclick="zoom(this)">
+`, *ids, depth, x, width, f.value, html.EscapeString(f.name))
+	} else {
+		fmt.Fprintln(w, `<g class="func">`)
+	}
+	fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="rgb(%d,%d,%d)" rx="1"/>
 `, x, y-frameHeight, width, frameHeight-1, r, g, b)
 
 	// Add text if frame is wide enough
-	if width > 40 {
+	if width > 40 || ids != nil {
 		label := f.name
+		if width <= 40 {
+			label = ""
+		}
 		maxChars := (width - 4) / 7 // approximate char width
 		if len(label) > maxChars {
 			if maxChars > 3 {
@@ -149,13 +223,13 @@ func renderFrame(w io.Writer, f *frame, x, baseY, width, frameHeight, fontSize,
 				label = ""
 			}
 		}
-		if label != "" {
+		if label != "" || ids != nil {
 			fmt.Fprintf(w, `<text x="%d" y="%d" fill="black">%s</text>
 `, x+2, y-4, html.EscapeString(label))
 		}
 	}
 
-	pctStr := fmt.Sprintf("%.1f%%", float64(f.value)/float64(totalSamples)*100)
+	pctStr := fmt.Sprintf("%.1f%%", float64(f.value)/float64(l.totalSamples)*100)
 	fmt.Fprintf(w, `<title>%s (%d samples, %s)</title>
 </g>
 `, html.EscapeString(f.name), f.value, pctStr)
@@ -167,17 +241,164 @@ func renderFrame(w io.Writer, f *frame, x, baseY, width, frameHeight, fontSize,
 	}
 	sort.Strings(childNames)
 
-	// Render children
+	// Render children, setting aside any too narrow to see
 	childX := x
+	other := newFrame("(other)")
 	for _, name := range childNames {
 		child := f.children[name]
 		childWidth := int(float64(width) * float64(child.value) / float64(f.value))
+		if childWidth < l.minWidth {
+			other.value += child.value
+			continue
+		}
 		if childWidth < 1 {
 			childWidth = 1
 		}
-		renderFrame(w, child, childX, baseY, childWidth, frameHeight, fontSize, totalSamples, depth+1, scheme)
+		renderFrame(w, child, childX, childWidth, depth+1, l)
 		childX += childWidth
 	}
+	if other.value > 0 {
+		renderFrame(w, other, childX, max(int(float64(width)*float64(other.value)/float64(f.value)), 1), depth+1, l)
+	}
+}
+
+// renderGridlines draws faint vertical lines at 25/50/75% of the chart width,
+// labelled with the share of samples to the left of each line.
+func renderGridlines(w io.Writer, x, top, bottom, width int) {
+	fmt.Fprintln(w, `<g id="gridlines" style="pointer-events:none">`)
+	for _, pct := range []int{25, 50, 75} {
+		gx := x + width*pct/100
+		fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#000" stroke-opacity="0.2" stroke-dasharray="2,3"/>
+<text x="%d" y="%d" text-anchor="middle" style="font-size:10px; fill:#999;">%d%%</text>
+`, gx, top, gx, bottom, gx, top-4, pct)
+	}
+	fmt.Fprintln(w, "</g>")
+}
+
+// zoomScript implements click-to-zoom in the style of flamegraph.pl. Clicking
+// a frame stretches it across the chart and scales its descendants by the
+// same factor; ancestors span the full width and unrelated frames are
+// hidden. Labels are re-truncated with the renderer's ~7px per character.
+// The gridline percentages only hold unzoomed, so they hide while zoomed.
+const zoomScript = `
+var chartX = %d, chartWidth = %d;
+function num(g, attr) { return parseInt(g.getAttribute(attr), 10); }
+function place(g, x, width) {
+  var rect = g.querySelector("rect"), text = g.querySelector("text");
+  rect.setAttribute("x", x);
+  rect.setAttribute("width", width);
+  if (!text) return;
+  var name = g.getAttribute("data-name"), maxChars = Math.floor((width - 4) / 7);
+  if (width <= 40 || maxChars <= 3) name = "";
+  else if (name.length > maxChars) name = name.substring(0, maxChars - 2) + "..";
+  text.setAttribute("x", x + 2);
+  text.textContent = name;
+}
+function zoom(target) {
+  var tx = num(target, "data-x"), tw = num(target, "data-width"), td = num(target, "data-depth");
+  var scale = chartWidth / tw;
+  var frames = document.querySelectorAll("g.func");
+  for (var i = 0; i < frames.length; i++) {
+    var g = frames[i], x = num(g, "data-x"), w = num(g, "data-width"), d = num(g, "data-depth");
+    if (d >= td && x >= tx && x < tx + tw) {
+      g.style.display = "";
+      place(g, chartX + (x - tx) * scale, Math.min(w, tx + tw - x) * scale);
+    } else if (d < td && x <= tx && tx < x + w) {
+      g.style.display = "";
+      place(g, chartX, chartWidth);
+    } else {
+      g.style.display = "none";
+    }
+  }
+  document.getElementById("unzoom").style.display = "";
+  var grid = document.getElementById("gridlines");
+  if (grid) grid.style.display = "none";
+}
+function unzoom() {
+  var frames = document.querySelectorAll("g.func");
+  for (var i = 0; i < frames.length; i++) {
+    frames[i].style.display = "";
+    place(frames[i], num(frames[i], "data-x"), num(frames[i], "data-width"));
+  }
+  document.getElementById("unzoom").style.display = "none";
+  var grid = document.getElementById("gridlines");
+  if (grid) grid.style.display = "";
+}
+`
+
+// searchScript implements the "Search" link: it prompts for a substring,
+// tints matching frames magenta and shows their share of all samples. A match
+// nested inside another match (recursion, or a name matching twice in one
+// stack) is only counted once, so the total never exceeds 100%%. Clicking the
+// link again resets the search.
+const searchScript = `
+var totalSamples = %d, searching = false;
+function search() {
+  if (searching) { resetSearch(); return; }
+  var term = prompt("Search frames for (substring):", "");
+  if (!term) return;
+  var frames = document.querySelectorAll("g.func"), matches = [];
+  for (var i = 0; i < frames.length; i++) {
+    var g = frames[i], rect = g.querySelector("rect");
+    if (g.getAttribute("data-name").indexOf(term) < 0) continue;
+    if (!rect.hasAttribute("data-fill")) rect.setAttribute("data-fill", rect.getAttribute("fill"));
+    rect.setAttribute("fill", "rgb(230,0,230)");
+    matches.push(g);
+  }
+  matches.sort(function(a, b) {
+    return num(a, "data-x") - num(b, "data-x") || num(a, "data-depth") - num(b, "data-depth");
+  });
+  var matched = 0, end = -1;
+  for (var j = 0; j < matches.length; j++) {
+    var x = num(matches[j], "data-x");
+    if (x < end) continue; // inside a match already counted
+    matched += num(matches[j], "data-samples");
+    end = x + num(matches[j], "data-width");
+  }
+  searching = true;
+  document.getElementById("search").textContent = "Reset Search";
+  document.getElementById("matched").textContent =
+    "Matched: " + (100 * matched / totalSamples).toFixed(1) + "%%";
+}
+function resetSearch() {
+  var rects = document.querySelectorAll("g.func rect[data-fill]");
+  for (var i = 0; i < rects.length; i++) {
+    rects[i].setAttribute("fill", rects[i].getAttribute("data-fill"));
+    rects[i].removeAttribute("data-fill");
+  }
+  searching = false;
+  document.getElementById("search").textContent = "Search";
+  document.getElementById("matched").textContent = "";
+}
+`
+
+// renderInteractive adds the "Reset Zoom" and "Search" links, the matched
+// percentage readout, and the scripts behind them.
+func renderInteractive(w io.Writer, chartX, chartWidth, width, totalSamples int) {
+	fmt.Fprintf(w, `<text id="unzoom" x="%d" y="20" onclick="unzoom()" style="display:none; cursor:pointer; font-size:12px; fill:#06c;">Reset Zoom</text>
+<text id="search" x="%d" y="20" text-anchor="end" onclick="search()" style="cursor:pointer; font-size:12px; fill:#06c;">Search</text>
+<text id="matched" x="%d" y="35" text-anchor="end" style="font-size:12px; fill:#666;"></text>
+`, chartX, width-chartX, width-chartX)
+	fmt.Fprintf(w, "<script type=\"text/ecmascript\"><![CDATA[%s%s]]></script>\n",
+		fmt.Sprintf(zoomScript, chartX, chartWidth), fmt.Sprintf(searchScript, totalSamples))
+}
+
+// renderLegend draws the color key for the scheme and explains frame width.
+func renderLegend(w io.Writer, x, y int, scheme string) {
+	if scheme == "" {
+		scheme = "hot"
+	}
+	const swatch = 12
+	fmt.Fprintf(w, `<text x="%d" y="%d" style="font-size:11px; fill:#666;">color = stack depth (%s):</text>
+`, x, y, html.EscapeString(scheme))
+	sx := x + 200
+	for depth := 0; depth < 6; depth++ {
+		r, g, b := frameColor(depth, scheme)
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="rgb(%d,%d,%d)"/>
+`, sx+depth*(swatch+2), y-swatch+2, swatch, swatch, r, g, b)
+	}
+	fmt.Fprintf(w, `<text x="%d" y="%d" style="font-size:11px; fill:#666;">root → leaf    width = %% of samples</text>
+`, sx+6*(swatch+2)+6, y)
 }
 
 func frameColor(depth int, scheme string) (int, int, int) {
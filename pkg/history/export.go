@@ -0,0 +1,46 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvTimeFormat is recognised as a date/time by Excel and Google Sheets.
+const csvTimeFormat = "2006-01-02 15:04:05"
+
+// Export writes samples as a raw series in the given format ("csv" or "json").
+func Export(w io.Writer, samples []Sample, format string) error {
+	switch format {
+	case "", "csv":
+		return WriteCSV(w, samples)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(samples)
+	default:
+		return fmt.Errorf("unsupported export format %q (want csv or json)", format)
+	}
+}
+
+// WriteCSV writes samples as timestamp,value rows with a header, ready for
+// spreadsheet trend charts.
+func WriteCSV(w io.Writer, samples []Sample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "value"}); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		row := []string{
+			s.Timestamp.Local().Format(csvTimeFormat),
+			strconv.FormatFloat(s.RawValue, 'f', -1, 64),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,135 @@
+// Package history keeps a time series of check results so trends can be
+// extracted for capacity planning.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/cache"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Sample is one recorded check value. Samples are stored one JSON object per
+// line so recording a run is a cheap append.
+type Sample struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Resource  string         `json:"resource"`
+	Type      use.MetricType `json:"type"`
+	Kind      string         `json:"kind,omitempty"`
+	RawValue  float64        `json:"raw_value"`
+	Unit      use.Unit       `json:"unit,omitempty"`
+	Status    use.Status     `json:"status"`
+}
+
+// Filter selects samples for a single series.
+type Filter struct {
+	Resource string         // exact match, e.g. "Filesystem (/)"; empty matches all
+	Type     use.MetricType // empty matches all
+	Kind     string         // e.g. "inodes"; with Resource set, empty matches only checks without a kind
+	Since    time.Time      // zero matches all
+}
+
+// DefaultPath returns the default history file path.
+func DefaultPath() string {
+	return filepath.Join(cache.StateDir(), "history.jsonl")
+}
+
+// Recorder appends each run to the history file at Path (DefaultPath when
+// empty). Pass it to use.Checker.SetRecorder to record every run.
+type Recorder struct {
+	Path string
+}
+
+// Record appends checks taken at the given time.
+func (r Recorder) Record(checks []use.Check, at time.Time) error {
+	return Append(r.Path, checks, at)
+}
+
+// Append records checks taken at the given time. Unknown checks are skipped
+// since they carry no value.
+func Append(path string, checks []use.Check, at time.Time) error {
+	if path == "" {
+		path = DefaultPath()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("cannot create state directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open history: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			continue
+		}
+		s := Sample{Timestamp: at, Resource: c.Resource, Type: c.Type, Kind: c.Kind, RawValue: c.RawValue, Unit: c.Unit, Status: c.Status}
+		if err := enc.Encode(s); err != nil {
+			return fmt.Errorf("cannot write history: %w", err)
+		}
+	}
+	return w.Flush()
+}
+
+// Query returns the samples matching filter, oldest first. Malformed lines
+// (e.g. a partial write) are skipped.
+func Query(path string, filter Filter) ([]Sample, error) {
+	if path == "" {
+		path = DefaultPath()
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read history: %w", err)
+	}
+	defer f.Close()
+
+	var samples []Sample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s Sample
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		if filter.Resource != "" && s.Resource != filter.Resource {
+			continue
+		}
+		if filter.Type != "" && s.Type != filter.Type {
+			continue
+		}
+		if (filter.Resource != "" || filter.Kind != "") && s.Kind != filter.Kind {
+			continue
+		}
+		if !filter.Since.IsZero() && s.Timestamp.Before(filter.Since) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	return samples, scanner.Err()
+}
+
+// ParseSince parses a lookback window such as "30d", "12h" or "90m". Days are
+// accepted in addition to time.ParseDuration units.
+func ParseSince(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid window %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q", s)
+	}
+	return d, nil
+}
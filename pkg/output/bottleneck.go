@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// BottleneckSummary states the likely bottleneck with its strongest evidence,
+// e.g. "System appears I/O-bound (Disk (sda) saturation high, CPU idle)."
+// It returns "" when no resource is meaningfully saturated.
+func BottleneckSummary(checks []use.Check) string {
+	class, confidence := use.ClassifyBottleneck(checks)
+	if class == "" {
+		return ""
+	}
+
+	var evidence []string
+	var best use.Check
+	var bestScore float64
+	for _, c := range checks {
+		if use.BottleneckClass(c.Resource) != class {
+			continue
+		}
+		if s := use.SaturationScore(c); s > bestScore {
+			best, bestScore = c, s
+		}
+	}
+	if bestScore > 0 {
+		evidence = append(evidence, fmt.Sprintf("%s saturation high", best.Resource))
+	}
+
+	// Contrast with CPU so an I/O or memory bottleneck isn't mistaken for load
+	if class != use.BottleneckCPU {
+		for _, c := range checks {
+			if c.Resource == "CPU" && c.Type == use.Utilization && c.Status != use.StatusUnknown {
+				if c.RawValue < 30 {
+					evidence = append(evidence, "CPU idle")
+				} else {
+					evidence = append(evidence, fmt.Sprintf("CPU %.0f%% busy", c.RawValue))
+				}
+				break
+			}
+		}
+	}
+
+	hedge := "appears"
+	if confidence < 0.3 {
+		hedge = "may be"
+	}
+	return fmt.Sprintf("System %s %s-bound (%s).", hedge, class, strings.Join(evidence, ", "))
+}
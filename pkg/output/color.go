@@ -0,0 +1,46 @@
+package output
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// SetColor forces styled output on or off. Styles across umd (table,
+// benchmark, baseline, crosscheck, workload) share lipgloss's default
+// renderer, so this applies to every report, not just one Formatter.
+func SetColor(enabled bool) {
+	if enabled {
+		lipgloss.SetColorProfile(termenv.ANSI256)
+		return
+	}
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// AutoColor enables color only when w is a terminal, honoring NO_COLOR and
+// CLICOLOR_FORCE. Without it lipgloss inspects stdout, so output written to
+// a file while stdout is a terminal would still carry escape codes.
+func AutoColor(w io.Writer) {
+	lipgloss.SetColorProfile(termenv.NewOutput(w).EnvColorProfile())
+}
+
+// ColorEnabled reports whether renderers emit styling. It is false when
+// NO_COLOR is set to a non-empty value (https://no-color.org), when output
+// isn't a terminal, or after SetColor(false); SetColor(true) overrides all
+// three. The baseline, benchmark, crosscheck, workload and debug renderers
+// style through lipgloss's default renderer, so they follow this setting
+// without calling it; it is for code that does more than pick a style.
+func ColorEnabled() bool {
+	return lipgloss.ColorProfile() != termenv.Ascii
+}
+
+// autoColorFile applies AutoColor when w is a file (stdout, stderr or a
+// redirect target). Other writers, such as HTTP responses, leave the global
+// setting alone so they can't strip color from a concurrent terminal report.
+func autoColorFile(w io.Writer) {
+	if _, ok := w.(*os.File); ok {
+		AutoColor(w)
+	}
+}
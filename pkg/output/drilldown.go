@@ -20,6 +20,42 @@ func DrillDown(check use.Check) []Suggestion {
 	var suggestions []Suggestion
 
 	switch {
+	case strings.HasPrefix(resource, "psi"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"cat", "cat /proc/pressure/{cpu,memory,io}", "Stall percentages over 10s/60s/300s"},
+				Suggestion{"top", "top -o %CPU", "Which processes are competing"},
+				Suggestion{"cgroup", "cat /sys/fs/cgroup/*/cpu.pressure", "Per-cgroup stalls (cgroup v2)"},
+			)
+		}
+
+	case strings.HasPrefix(resource, "irq"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"watch", "watch -d -n1 cat /proc/interrupts", "Which IRQ lines and CPUs are climbing"},
+				Suggestion{"mpstat", "mpstat -P ALL 1 5", "Per-core %irq and %soft time"},
+				Suggestion{"irqbalance", "cat /proc/irq/*/smp_affinity_list", "Check interrupt affinity across cores"},
+			)
+		}
+
+	case strings.HasPrefix(resource, "entropy"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"uname", "uname -r", "Kernels 5.18+ no longer block on low entropy"},
+				Suggestion{"rngd", "systemctl status rngd haveged", "Check an entropy daemon is running"},
+				Suggestion{"virtio-rng", "cat /sys/class/misc/hw_random/rng_current", "VMs: attach a virtio-rng device"},
+			)
+		}
+
+	case strings.HasPrefix(resource, "thermal"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"sensors", "sensors", "Per-sensor temperatures (lm-sensors, Linux)"},
+				Suggestion{"turbostat", "sudo turbostat --quiet --show Core,Bzy_MHz,CoreTmp,PkgTmp -i 1", "Effective clock vs temperature per core (Linux)"},
+				Suggestion{"pmset", "pmset -g thermlog", "Watch thermal and speed-limit changes (macOS)"},
+			)
+		}
+
 	case strings.Contains(resource, "cpu"):
 		if check.Status == use.StatusError || check.Status == use.StatusWarning {
 			suggestions = append(suggestions,
@@ -92,6 +128,63 @@ func DrillDown(check use.Check) []Suggestion {
 			suggestions = append(suggestions,
 				Suggestion{"vmstat", "vmstat 1 5", "Virtual memory statistics"},
 			)
+			if strings.Contains(resource, "thp") {
+				suggestions = append(suggestions,
+					Suggestion{"grep", "grep -E 'compact_|thp_' /proc/vmstat", "Compaction and THP counters"},
+					Suggestion{"cat", "cat /sys/kernel/mm/transparent_hugepage/defrag", "THP defrag policy (madvise or never avoids direct stalls)"},
+				)
+			}
+		}
+
+	case strings.Contains(resource, "membw"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"powermetrics", "sudo powermetrics --samplers bandwidth,gpu_power,ane_power -i 1000 -n 5", "Sample unified memory bandwidth and accelerators"},
+				Suggestion{"umd", "umd workload --top 10", "Find bandwidth-heavy processes"},
+			)
+		}
+
+	case strings.Contains(resource, "gpu"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"powermetrics", "sudo powermetrics --samplers gpu_power -i 1000 -n 5", "Sample GPU residency and frequency"},
+				Suggestion{"ioreg", "ioreg -r -d 1 -c IOAccelerator | grep PerformanceStatistics", "GPU memory and utilization counters"},
+			)
+		}
+
+	case strings.Contains(resource, "systemd"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"systemctl", "systemctl --failed", "List failed units"},
+				Suggestion{"journalctl", "journalctl -p err -b", "Errors logged since boot"},
+			)
+		}
+
+	case strings.HasPrefix(resource, "cgroup"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"cgroup", "cat /sys/fs/cgroup/memory.stat", "What the container's memory is spent on"},
+				Suggestion{"cgroup", "cat /sys/fs/cgroup/cpu.max /sys/fs/cgroup/cpu.stat", "CPU quota and throttling counters"},
+				Suggestion{"kubectl", "kubectl top pod", "Usage against requests/limits (Kubernetes)"},
+			)
+		}
+
+	case strings.HasPrefix(resource, "numa"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"numastat", "numastat -m", "Per-node memory breakdown"},
+				Suggestion{"numastat", "numastat -p <pid>", "Which nodes a process's memory lives on"},
+				Suggestion{"numactl", "numactl --hardware", "Node sizes and distances"},
+			)
+		}
+
+	case strings.Contains(resource, "audit"):
+		if check.Status == use.StatusError || check.Status == use.StatusWarning {
+			suggestions = append(suggestions,
+				Suggestion{"auditctl", "sudo auditctl -s", "Backlog, backlog_limit and lost events"},
+				Suggestion{"aureport", "sudo aureport --summary -ts recent", "Which rules generate the most events"},
+				Suggestion{"auditctl", "sudo auditctl -b 8192", "Raise backlog_limit if bursts overflow it"},
+			)
 		}
 	}
 
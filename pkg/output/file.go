@@ -0,0 +1,38 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// RenderFile renders checks to path, writing a temp file in the same
+// directory and renaming it so readers such as node_exporter's textfile
+// collector never see a partial file.
+func RenderFile(path string, format Format, checks []use.Check) error {
+	var buf bytes.Buffer
+	if err := NewFormatter(format, &buf).Render(checks); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("cannot create output file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write output file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write output file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("cannot write output file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
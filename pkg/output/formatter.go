@@ -2,13 +2,17 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/danpilch/umd/pkg/baseline"
 	"github.com/danpilch/umd/pkg/use"
 )
 
@@ -16,25 +20,45 @@ import (
 type Format string
 
 const (
-	FormatTable Format = "table"
-	FormatJSON  Format = "json"
-	FormatAI    Format = "ai"
-	FormatTSV   Format = "tsv"
+	FormatTable      Format = "table"
+	FormatJSON       Format = "json"
+	FormatAI         Format = "ai"
+	FormatTSV        Format = "tsv"
+	FormatCSV        Format = "csv"
+	FormatYAML       Format = "yaml"
+	FormatNagios     Format = "nagios"
+	FormatPrometheus Format = "prometheus"
+	FormatHTML       Format = "html"
+	FormatJSONL      Format = "jsonl"
 )
 
 // Formatter handles output formatting.
 type Formatter struct {
-	format    Format
-	writer    io.Writer
-	sparkline *SparklineTracker
-	showScore bool
+	format      Format
+	writer      io.Writer
+	sparkline   *SparklineTracker
+	showScore   bool
+	weights     ScoreWeights
+	valueFormat *ValueFormat
+	runbooks    *RunbookResolver
+	rolling     *baseline.RollingStats
+	anomalies   map[string]string
+	glyphs      GlyphMode
+	palette     Palette
+	thresholds  *use.Thresholds
+	openMetrics bool
+	onlyIssues  bool
+	flameGraph  []byte
 }
 
-// NewFormatter creates a new formatter.
+// NewFormatter creates a new formatter. When writer is a file, styling is
+// enabled only if it is a terminal; see SetColor to override.
 func NewFormatter(format Format, writer io.Writer) *Formatter {
+	autoColorFile(writer)
 	return &Formatter{
-		format: format,
-		writer: writer,
+		format:  format,
+		writer:  writer,
+		palette: PaletteDefault,
 	}
 }
 
@@ -43,18 +67,106 @@ func (f *Formatter) SetSparklineTracker(s *SparklineTracker) {
 	f.sparkline = s
 }
 
+// SetColor forces styled output on or off regardless of terminal detection.
+// It applies to all umd renderers; see the package-level SetColor.
+func (f *Formatter) SetColor(enabled bool) {
+	SetColor(enabled)
+}
+
+// SetOpenMetrics switches the prometheus format from the legacy text
+// exposition to strict OpenMetrics: unit-suffixed families with UNIT lines,
+// values in base units (ratios rather than percentages) and a final # EOF.
+func (f *Formatter) SetOpenMetrics(enabled bool) {
+	f.openMetrics = enabled
+}
+
+// SetOnlyIssues limits output to WARNING, ERROR and UNKNOWN checks in every
+// format, so a cron email shows only problems. With none left, table and AI
+// output print "All checks passed"; other formats render an empty set.
+func (f *Formatter) SetOnlyIssues(enabled bool) {
+	f.onlyIssues = enabled
+}
+
 // SetShowScore enables health score display.
 func (f *Formatter) SetShowScore(show bool) {
 	f.showScore = show
 }
 
+// SetScoreWeights weights each check's deduction from the health score.
+func (f *Formatter) SetScoreWeights(w ScoreWeights) {
+	f.weights = w
+}
+
+// SetValueFormat re-renders check values from RawValue and Unit using the
+// given format instead of the collector's preformatted Value.
+func (f *Formatter) SetValueFormat(vf ValueFormat) {
+	f.valueFormat = &vf
+}
+
+// SetRunbookResolver enables runbook links for failing checks.
+func (f *Formatter) SetRunbookResolver(r *RunbookResolver) {
+	f.runbooks = r
+}
+
+// SetRollingStats enables self-calibrating anomaly detection for watch mode.
+// Each Render compares checks against the session's rolling baseline, then
+// folds them into it.
+func (f *Formatter) SetRollingStats(r *baseline.RollingStats) {
+	f.rolling = r
+}
+
+// SetGlyphs marks statuses with glyphs or short labels so they are
+// distinguishable without color.
+func (f *Formatter) SetGlyphs(mode GlyphMode) {
+	f.glyphs = mode
+}
+
+// SetPalette selects the status color palette.
+func (f *Formatter) SetPalette(p Palette) {
+	f.palette = p
+}
+
+// SetThresholds supplies the thresholds checks were evaluated with, for
+// formats that report them alongside values (Nagios perfdata, the AI
+// format's threshold notes). In the table
+// format it also colors each sparkline character by its value's status.
+func (f *Formatter) SetThresholds(t use.Thresholds) {
+	f.thresholds = &t
+}
+
 // Render outputs the checks in the configured format.
 func (f *Formatter) Render(checks []use.Check) error {
 	// Record sparkline data if tracker is set
 	if f.sparkline != nil {
 		for _, c := range checks {
-			key := c.Resource + "|" + string(c.Type)
-			f.sparkline.Record(key, c.RawValue)
+			f.sparkline.Record(c.Key(), c.RawValue)
+		}
+	}
+
+	// Score against the rolling baseline before updating it with this sample
+	if f.rolling != nil {
+		f.anomalies = make(map[string]string)
+		for _, c := range checks {
+			f.anomalies[c.Key()] = f.rolling.Anomaly(c)
+		}
+		f.rolling.Update(checks)
+	}
+
+	if f.valueFormat != nil {
+		checks = applyValueFormat(checks, *f.valueFormat)
+	}
+
+	// Concurrent collection finishes in any order; sort a copy so text
+	// output diffs cleanly between runs
+	checks = slices.Clone(checks)
+	use.SortChecks(checks)
+
+	// Filter after recording so sparklines and anomalies keep full history
+	if f.onlyIssues {
+		checks = issuesOnly(checks)
+		if len(checks) == 0 && (f.format == FormatTable || f.format == FormatAI) {
+			fmt.Fprintln(f.writer, "All checks passed")
+			return nil
 		}
 	}
 
@@ -65,24 +177,54 @@ func (f *Formatter) Render(checks []use.Check) error {
 		return f.renderAI(checks)
 	case FormatTSV:
 		return f.renderTSV(checks)
+	case FormatCSV:
+		return f.renderCSV(checks)
+	case FormatYAML:
+		return f.renderYAML(checks)
+	case FormatNagios:
+		return f.renderNagios(checks)
+	case FormatPrometheus:
+		return f.renderPrometheus(checks)
+	case FormatHTML:
+		return f.renderHTML(checks)
+	case FormatJSONL:
+		return f.renderJSONL(checks)
 	default:
 		return f.renderTable(checks)
 	}
 }
 
-// renderJSON outputs checks as JSON.
-func (f *Formatter) renderJSON(checks []use.Check) error {
-	output := struct {
-		Checks  []use.Check `json:"checks"`
-		Summary use.Summary `json:"summary"`
-	}{
-		Checks:  checks,
-		Summary: use.Summarize(checks),
+// issuesOnly returns the checks that are not OK.
+func issuesOnly(checks []use.Check) []use.Check {
+	issues := make([]use.Check, 0)
+	for _, c := range checks {
+		if c.Status != use.StatusOK {
+			issues = append(issues, c)
+		}
 	}
+	return issues
+}
 
+// renderJSON outputs checks as JSON.
+func (f *Formatter) renderJSON(checks []use.Check) error {
 	enc := json.NewEncoder(f.writer)
 	enc.SetIndent("", "  ")
-	return enc.Encode(output)
+	return enc.Encode(f.document(checks))
+}
+
+// document is the structure shared by the JSON and YAML formats.
+type document struct {
+	Checks   []use.Check   `json:"checks"`
+	Summary  use.Summary   `json:"summary"`
+	Runbooks []RunbookLink `json:"runbooks,omitempty"`
+}
+
+func (f *Formatter) document(checks []use.Check) document {
+	return document{
+		Checks:   checks,
+		Summary:  use.Summarize(checks),
+		Runbooks: f.runbooks.Links(checks),
+	}
 }
 
 // renderTable outputs checks as a styled table.
@@ -97,12 +239,7 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 	cellStyle := lipgloss.NewStyle().Padding(0, 1)
 
 	// Status colors
-	statusStyles := map[use.Status]lipgloss.Style{
-		use.StatusOK:      lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true), // Green
-		use.StatusWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true), // Yellow
-		use.StatusError:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true),  // Red
-		use.StatusUnknown: lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Bold(true),  // Gray
-	}
+	statusStyles := StatusStyles(f.palette)
 
 	// Print header
 	titleStyle := lipgloss.NewStyle().
@@ -116,6 +253,9 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 
 	// Build table data - add sparkline column if tracker is set
 	hasSparklines := f.sparkline != nil
+	trendStyles := f.trendStyles()
+	hasAnomalies := f.rolling != nil
+	anomalyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Bold(true)
 	rows := make([][]string, len(checks))
 	for i, check := range checks {
 		statusStyle := statusStyles[check.Status]
@@ -123,11 +263,19 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 			check.Resource,
 			string(check.Type),
 			check.Value,
-			statusStyle.Render(strings.ToUpper(string(check.Status))),
+			statusStyle.Render(StatusLabel(check.Status, f.glyphs)),
 		}
 		if hasSparklines {
-			key := check.Resource + "|" + string(check.Type)
-			row = append(row, f.sparkline.Sparkline(key))
+			key := check.Key()
+			// Non-percentage utilization (byte rates) has no thresholds to color by
+			if trendStyles != nil && (check.Type != use.Utilization || check.Unit == use.UnitPercent) {
+				row = append(row, f.sparkline.sparklineStyled(key, *f.thresholds, trendStyles))
+			} else {
+				row = append(row, f.sparkline.Sparkline(key))
+			}
+		}
+		if hasAnomalies {
+			row = append(row, anomalyStyle.Render(f.anomalies[check.Key()]))
 		}
 		rows[i] = row
 	}
@@ -137,6 +285,9 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 	if hasSparklines {
 		headers = append(headers, "TREND")
 	}
+	if hasAnomalies {
+		headers = append(headers, "ANOMALY")
+	}
 
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
@@ -156,10 +307,13 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 	summary := use.Summarize(checks)
 	fmt.Fprintln(f.writer)
 	f.renderSummary(summary, statusStyles)
+	if b := BottleneckSummary(checks); b != "" {
+		fmt.Fprintln(f.writer, b)
+	}
 
 	// Show health score if enabled
 	if f.showScore {
-		score := HealthScore(checks)
+		score, _ := f.weights.Score(checks)
 		label := ScoreLabel(score)
 		scoreStyle := statusStyles[use.StatusOK]
 		if score < 80 {
@@ -172,9 +326,33 @@ func (f *Formatter) renderTable(checks []use.Check) error {
 			scoreStyle.Render(fmt.Sprintf("%d/100 (%s)", score, label)))
 	}
 
+	// List runbooks for failing checks
+	if links := f.runbooks.Links(checks); len(links) > 0 {
+		fmt.Fprintln(f.writer)
+		fmt.Fprintln(f.writer, titleStyle.Render("Runbooks"))
+		for _, l := range links {
+			fmt.Fprintf(f.writer, "  %s %s: %s\n", l.Resource, l.Type, l.URL)
+		}
+	}
+
 	return nil
 }
 
+// trendStyles returns status styles for coloring sparklines, or nil when
+// trends should stay plain: no thresholds to judge values by, or color
+// disabled by the palette, NO_COLOR or a non-terminal output. Skipping the
+// styles also skips classifying every sample against the thresholds.
+func (f *Formatter) trendStyles() map[use.Status]lipgloss.Style {
+	if f.thresholds == nil || f.palette == PaletteNone || !ColorEnabled() {
+		return nil
+	}
+	styles := StatusStyles(f.palette)
+	for status, style := range styles {
+		styles[status] = style.UnsetBold()
+	}
+	return styles
+}
+
 // renderSummary outputs the summary line.
 func (f *Formatter) renderSummary(summary use.Summary, styles map[use.Status]lipgloss.Style) {
 	parts := []string{}
@@ -210,6 +388,21 @@ func (f *Formatter) renderAI(checks []use.Check) error {
 		fmt.Fprintf(f.writer, "\n**Status:** %d errors, %d warnings, %d ok\n\n",
 			summary.Errors, summary.Warnings, summary.OK)
 	}
+	if b := BottleneckSummary(checks); b != "" {
+		fmt.Fprintf(f.writer, "**Bottleneck:** %s\n\n", b)
+	}
+
+	// Health score with the deductions behind it
+	score, deducted := f.weights.Score(checks)
+	fmt.Fprintf(f.writer, "**Health Score:** %d/100 (%s)\n", score, ScoreLabel(score))
+	for _, d := range deducted {
+		weight := ""
+		if d.Weight != 1 {
+			weight = fmt.Sprintf(", weight %g", d.Weight)
+		}
+		fmt.Fprintf(f.writer, "- -%g %s %s (%s%s)\n", d.Points, d.Resource, d.Type, d.Status, weight)
+	}
+	fmt.Fprintln(f.writer)
 
 	// Group checks by resource
 	resourceChecks := make(map[string][]use.Check)
@@ -234,6 +427,9 @@ func (f *Formatter) renderAI(checks []use.Check) error {
 			fmt.Fprintf(f.writer, "- **[%s] %s %s:** %s\n",
 				severity, check.Resource, check.Type, check.Value)
 			fmt.Fprintf(f.writer, "  - %s\n", getAIInterpretation(check))
+			if url := f.runbooks.Resolve(check); url != "" {
+				fmt.Fprintf(f.writer, "  - Runbook: %s\n", url)
+			}
 		}
 		fmt.Fprintln(f.writer)
 	}
@@ -272,7 +468,9 @@ func (f *Formatter) renderAI(checks []use.Check) error {
 	fmt.Fprintln(f.writer, "- **Saturation**: Work waiting/queued (non-zero = resource is bottleneck)")
 	fmt.Fprintln(f.writer, "- **Errors**: Hardware/software errors (any > 0 needs investigation)")
 	fmt.Fprintln(f.writer)
-	fmt.Fprintln(f.writer, "Thresholds: Warning ≥70%, Critical ≥90% for utilization metrics.")
+	for _, line := range f.aiThresholdLines(checks) {
+		fmt.Fprintln(f.writer, line)
+	}
 
 	// Drill-down suggestions for issues
 	suggestions := GetDrillDownSuggestions(checks)
@@ -295,17 +493,36 @@ func (f *Formatter) renderAI(checks []use.Check) error {
 // renderTSV outputs checks as tab-separated values.
 func (f *Formatter) renderTSV(checks []use.Check) error {
 	// Header
-	fmt.Fprintln(f.writer, "RESOURCE\tTYPE\tVALUE\tRAW_VALUE\tSTATUS\tDESCRIPTION\tCOMMAND")
+	// UNIT is appended last so scripts reading columns by position keep working
+	fmt.Fprintln(f.writer, "RESOURCE\tTYPE\tVALUE\tRAW_VALUE\tSTATUS\tDESCRIPTION\tCOMMAND\tUNIT")
 
 	for _, c := range checks {
-		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%.4f\t%s\t%s\t%s\n",
-			c.Resource, c.Type, c.Value, c.RawValue,
-			c.Status, c.Description, c.Command)
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Resource, c.Type, c.Value, strconv.FormatFloat(c.RawValue, 'f', -1, 64),
+			c.Status, c.Description, c.Command, c.Unit)
 	}
 
 	return nil
 }
 
+// renderCSV outputs checks as RFC 4180 CSV with the same columns as TSV.
+// Fields containing commas, quotes or newlines are quoted, so descriptions
+// and commands survive spreadsheet and pandas imports intact.
+func (f *Formatter) renderCSV(checks []use.Check) error {
+	w := csv.NewWriter(f.writer)
+	w.Write([]string{"RESOURCE", "TYPE", "VALUE", "RAW_VALUE", "STATUS", "DESCRIPTION", "COMMAND", "UNIT"})
+
+	for _, c := range checks {
+		w.Write([]string{
+			c.Resource, string(c.Type), c.Value, strconv.FormatFloat(c.RawValue, 'f', -1, 64),
+			string(c.Status), c.Description, c.Command, string(c.Unit),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
 // filterByStatus returns checks matching any of the given statuses.
 func filterByStatus(checks []use.Check, statuses ...use.Status) []use.Check {
 	var result []use.Check
@@ -321,13 +538,36 @@ func filterByStatus(checks []use.Check, statuses ...use.Status) []use.Check {
 	return result
 }
 
+// aiThresholdLines describes the utilization thresholds checks were judged
+// against: the global values, then each resource whose overrides differ.
+// Without SetThresholds the defaults are assumed.
+func (f *Formatter) aiThresholdLines(checks []use.Check) []string {
+	t := use.DefaultThresholds()
+	if f.thresholds != nil {
+		t = *f.thresholds
+	}
+	lines := []string{fmt.Sprintf("Thresholds: Warning ≥%g%%, Critical ≥%g%% for utilization metrics.", t.WarnUtil, t.CritUtil)}
+
+	seen := make(map[string]bool)
+	for _, c := range checks {
+		if c.Type != use.Utilization || c.Unit != use.UnitPercent || seen[c.Resource] {
+			continue
+		}
+		seen[c.Resource] = true
+		if o := t.ForCheck(c); o.WarnUtil != t.WarnUtil || o.CritUtil != t.CritUtil {
+			lines = append(lines, fmt.Sprintf("- %s: Warning ≥%g%%, Critical ≥%g%%", c.Resource, o.WarnUtil, o.CritUtil))
+		}
+	}
+	return lines
+}
+
 // getAIInterpretation returns actionable context for a check.
 func getAIInterpretation(check use.Check) string {
 	resource := strings.ToLower(check.Resource)
 
 	switch check.Type {
 	case use.Utilization:
-		if check.RawValue >= 90 {
+		if check.Status == use.StatusError {
 			return "Critical: Resource near capacity. Immediate attention needed."
 		}
 		return "Elevated usage. Monitor for sustained high values."
@@ -360,6 +600,9 @@ func getAIInterpretation(check use.Check) string {
 		if strings.Contains(resource, "network") {
 			return "Network interface errors. Check cables, drivers, or hardware."
 		}
+		if strings.Contains(resource, "systemd") {
+			return "Failed systemd units. A failed service is often the direct cause of an incident."
+		}
 		return "Errors detected. Review system logs for details."
 	}
 
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// GlyphMode selects how status is marked independently of color.
+type GlyphMode string
+
+const (
+	GlyphsNone    GlyphMode = ""        // status text only
+	GlyphsUnicode GlyphMode = "unicode" // ✓ ! ✗ ? alongside the status text
+	GlyphsASCII   GlyphMode = "ascii"   // short OK/WARN/ERR/UNK labels
+)
+
+// Palette selects the colors used for statuses.
+type Palette string
+
+const (
+	PaletteDefault    Palette = "default"    // green/yellow/red
+	PaletteColorblind Palette = "colorblind" // Okabe-Ito blue/orange/vermillion
+	PaletteNone       Palette = "none"       // no color, bold only
+)
+
+var unicodeGlyphs = map[use.Status]string{
+	use.StatusOK:      "✓",
+	use.StatusWarning: "!",
+	use.StatusError:   "✗",
+	use.StatusUnknown: "?",
+}
+
+var asciiLabels = map[use.Status]string{
+	use.StatusOK:      "OK",
+	use.StatusWarning: "WARN",
+	use.StatusError:   "ERR",
+	use.StatusUnknown: "UNK",
+}
+
+var paletteColors = map[Palette]map[use.Status]lipgloss.Color{
+	PaletteDefault: {
+		use.StatusOK:      lipgloss.Color("10"), // Green
+		use.StatusWarning: lipgloss.Color("11"), // Yellow
+		use.StatusError:   lipgloss.Color("9"),  // Red
+		use.StatusUnknown: lipgloss.Color("8"),  // Gray
+	},
+	PaletteColorblind: {
+		use.StatusOK:      lipgloss.Color("#0072B2"), // Blue
+		use.StatusWarning: lipgloss.Color("#E69F00"), // Orange
+		use.StatusError:   lipgloss.Color("#D55E00"), // Vermillion
+		use.StatusUnknown: lipgloss.Color("#999999"), // Gray
+	},
+}
+
+// StatusStyles returns the status styles for a palette. Unknown palettes
+// fall back to the default.
+func StatusStyles(p Palette) map[use.Status]lipgloss.Style {
+	styles := make(map[use.Status]lipgloss.Style, 4)
+	colors, ok := paletteColors[p]
+	if !ok && p != PaletteNone {
+		colors = paletteColors[PaletteDefault]
+	}
+	for _, s := range []use.Status{use.StatusOK, use.StatusWarning, use.StatusError, use.StatusUnknown} {
+		style := lipgloss.NewStyle().Bold(true)
+		if c, ok := colors[s]; ok {
+			style = style.Foreground(c)
+		}
+		styles[s] = style
+	}
+	return styles
+}
+
+// StatusLabel returns the status text decorated according to the glyph mode.
+func StatusLabel(s use.Status, mode GlyphMode) string {
+	switch mode {
+	case GlyphsUnicode:
+		return unicodeGlyphs[s] + " " + strings.ToUpper(string(s))
+	case GlyphsASCII:
+		return asciiLabels[s]
+	}
+	return strings.ToUpper(string(s))
+}
@@ -0,0 +1,126 @@
+package output
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// htmlReport is the data behind the HTML template.
+type htmlReport struct {
+	Host       string
+	Generated  string
+	Checks     []use.Check
+	Summary    use.Summary
+	Score      int
+	ScoreLabel string
+	Deductions []ScoreContribution
+	Bottleneck string
+	Runbooks   map[string]string
+	FlameGraph template.HTML
+}
+
+// htmlTemplate is a self-contained page: styles are inline and nothing is
+// fetched, so the file can be attached to a ticket and opened anywhere.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>USE Method Report - {{.Host}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+  h1 { font-size: 1.4em; margin-bottom: 0.2em; }
+  .meta { color: #666; margin-bottom: 1.5em; }
+  table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+  th, td { text-align: left; padding: 0.35em 0.7em; border-bottom: 1px solid #ddd; }
+  th { background: #4b4a8c; color: #fff; }
+  tr.ok { background: #e6f4ea; }
+  tr.warning { background: #fff4d6; }
+  tr.error { background: #fde2e1; }
+  tr.unknown { background: #eee; color: #666; }
+  td.status { font-weight: bold; text-transform: uppercase; }
+  .summary span { margin-right: 1em; }
+  .flamegraph svg { max-width: 100%; height: auto; }
+</style>
+</head>
+<body>
+<h1>USE Method System Check</h1>
+<div class="meta">{{.Host}} &middot; {{.Generated}}</div>
+
+<table>
+<tr><th>Resource</th><th>Type</th><th>Value</th><th>Status</th><th>Description</th></tr>
+{{- range .Checks}}
+<tr class="{{.Status}}"><td>{{.Resource}}</td><td>{{.Type}}</td><td>{{.Value}}</td><td class="status">{{.Status}}</td><td>{{.Description}}
+{{- with index $.Runbooks (printf "%s|%s" .Resource .Type)}} (<a href="{{.}}">runbook</a>){{end}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Summary</h2>
+<p class="summary">
+{{- if or .Summary.Errors .Summary.Warnings .Summary.Unknown}}
+<span>{{.Summary.Errors}} errors</span><span>{{.Summary.Warnings}} warnings</span><span>{{.Summary.Unknown}} unknown</span><span>{{.Summary.OK}} ok</span>
+{{- else}}
+All checks passed
+{{- end}}
+</p>
+{{- with .Bottleneck}}
+<p><strong>Bottleneck:</strong> {{.}}</p>
+{{- end}}
+
+<h2>Health Score: {{.Score}}/100 ({{.ScoreLabel}})</h2>
+{{- if .Deductions}}
+<ul>
+{{- range .Deductions}}
+<li>-{{.Points}} {{.Resource}} {{.Type}} ({{.Status}})</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .FlameGraph}}
+
+<h2>CPU Flame Graph</h2>
+<div class="flamegraph">{{.}}</div>
+{{- end}}
+</body>
+</html>
+`))
+
+// SetFlameGraph inlines a flame graph SVG, e.g. from flamegraph.GenerateSVG,
+// in HTML output. The SVG's XML prologue is dropped so it embeds in HTML5.
+func (f *Formatter) SetFlameGraph(svg []byte) {
+	if i := bytes.Index(svg, []byte("<svg")); i >= 0 {
+		svg = svg[i:]
+	}
+	f.flameGraph = svg
+}
+
+// renderHTML outputs a self-contained HTML report: checks colored by status,
+// the summary, the health score and any flame graph set by SetFlameGraph.
+func (f *Formatter) renderHTML(checks []use.Check) error {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown host"
+	}
+	score, deducted := f.weights.Score(checks)
+
+	runbooks := make(map[string]string)
+	for _, l := range f.runbooks.Links(checks) {
+		runbooks[l.Resource+"|"+string(l.Type)] = l.URL
+	}
+
+	return htmlTemplate.Execute(f.writer, htmlReport{
+		Host:       host,
+		Generated:  time.Now().Format("2006-01-02 15:04:05 MST"),
+		Checks:     checks,
+		Summary:    use.Summarize(checks),
+		Score:      score,
+		ScoreLabel: ScoreLabel(score),
+		Deductions: deducted,
+		Bottleneck: BottleneckSummary(checks),
+		Runbooks:   runbooks,
+		// The SVG comes from our own flame graph renderer, not user input
+		FlameGraph: template.HTML(f.flameGraph),
+	})
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// jsonlLine is one JSON Lines record: a check stamped with the time of the
+// render it came from, so a log store can order lines from the same sample.
+type jsonlLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	use.Check
+	Runbook string `json:"runbook,omitempty"`
+}
+
+// renderJSONL outputs each check as its own unindented JSON object. Unlike
+// renderJSON there is no wrapper or summary, so every line stands alone.
+func (f *Formatter) renderJSONL(checks []use.Check) error {
+	now := time.Now().UTC()
+	enc := json.NewEncoder(f.writer)
+	for _, c := range checks {
+		line := jsonlLine{Timestamp: now, Check: c, Runbook: f.runbooks.Resolve(c)}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// nagiosStates names the Nagios plugin states by exit code. use.ExitCode
+// already follows the plugin convention: 0 OK, 1 WARNING, 2 CRITICAL, and 3
+// (umd's "tool error", when nothing could be measured) is UNKNOWN.
+var nagiosStates = [...]string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// NagiosState returns the Nagios state name for an exit code from
+// use.ExitCode.
+func NagiosState(code int) string {
+	if code < 0 || code >= len(nagiosStates) {
+		return "UNKNOWN"
+	}
+	return nagiosStates[code]
+}
+
+// nagiosTypeSuffix abbreviates USE types in perfdata labels.
+var nagiosTypeSuffix = map[use.MetricType]string{
+	use.Utilization: "util",
+	use.Saturation:  "sat",
+	use.Errors:      "err",
+}
+
+// renderNagios outputs a Nagios/Icinga plugin result: a status line with
+// perfdata after the pipe, then one line per failing check as long output.
+// The caller exits with use.ExitCode(checks) to report the state.
+//
+//	USE WARNING - 0 errors, 1 warnings | cpu_util=42.5%;70;90 disk_sda_sat=1.2;1
+//	WARNING: Disk (sda) saturation 1.20 - Average queue size
+func (f *Formatter) renderNagios(checks []use.Check) error {
+	summary := use.Summarize(checks)
+	state := NagiosState(use.ExitCode(checks))
+
+	line := fmt.Sprintf("USE %s - %d errors, %d warnings", state, summary.Errors, summary.Warnings)
+	if summary.Unknown > 0 {
+		line += fmt.Sprintf(", %d unknown", summary.Unknown)
+	}
+	if perf := f.nagiosPerfdata(checks); len(perf) > 0 {
+		line += " | " + strings.Join(perf, " ")
+	}
+	fmt.Fprintln(f.writer, line)
+
+	for _, c := range use.RankIssues(checks) {
+		fmt.Fprintf(f.writer, "%s: %s %s %s - %s\n",
+			nagiosStatusWord(c.Status), c.Resource, c.Type, c.Value, c.Description)
+	}
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			fmt.Fprintf(f.writer, "UNKNOWN: %s %s - %s\n", c.Resource, c.Type, c.Description)
+		}
+	}
+	return nil
+}
+
+// nagiosPerfdata returns label=value[UOM];warn;crit for each measured check.
+// Labels reuse the Prometheus sanitizing so both integrations agree.
+func (f *Formatter) nagiosPerfdata(checks []use.Check) []string {
+	seen := make(map[string]bool)
+	var perf []string
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			continue
+		}
+		label := promLabel(c.Resource) + "_" + nagiosTypeSuffix[c.Type]
+		if c.Kind != "" {
+			label += "_" + c.Kind
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+
+		entry := label + "=" + strconv.FormatFloat(c.RawValue, 'f', -1, 64) + nagiosUOM(c.Unit)
+		if warn, crit, ok := f.nagiosLimits(c); ok {
+			entry += ";" + strconv.FormatFloat(warn, 'f', -1, 64) + ";" + nagiosNumber(crit)
+		}
+		perf = append(perf, strings.TrimRight(entry, ";"))
+	}
+	return perf
+}
+
+// nagiosLimits returns the warning and critical thresholds a check was judged
+// against, if thresholds were set, resolved through the same collector and
+// resource overrides as its status. A zero crit means none.
+func (f *Formatter) nagiosLimits(c use.Check) (float64, float64, bool) {
+	if f.thresholds == nil {
+		return 0, 0, false
+	}
+	if l, ok := f.thresholds.SaturationLimitsFor(c.Resource, c.Type); ok {
+		return l.Warn, l.Crit, true
+	}
+	if c.Type == use.Utilization && c.Unit == use.UnitPercent {
+		t := f.thresholds.ForCheck(c)
+		return t.WarnUtil, t.CritUtil, true
+	}
+	return 0, 0, false
+}
+
+// nagiosUOM maps a unit to a Nagios perfdata unit of measure. Units Nagios
+// doesn't define (rates) are left bare.
+func nagiosUOM(u use.Unit) string {
+	switch u {
+	case use.UnitPercent:
+		return "%"
+	case use.UnitBytes:
+		return "B"
+	}
+	return ""
+}
+
+// nagiosNumber formats a critical threshold, leaving it empty when unset.
+func nagiosNumber(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// nagiosStatusWord names a warning or error check's state.
+func nagiosStatusWord(s use.Status) string {
+	if s == use.StatusError {
+		return "CRITICAL"
+	}
+	return "WARNING"
+}
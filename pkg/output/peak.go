@@ -0,0 +1,116 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Peak is the worst-case (maximum RawValue) sample of a check over a window.
+type Peak struct {
+	Check   use.Check `json:"check"`
+	At      time.Time `json:"at"`
+	Samples int       `json:"samples"`
+}
+
+// PeakTracker records the maximum RawValue per check across repeated samples.
+// Values are also recorded into a SparklineTracker for the trend column.
+type PeakTracker struct {
+	mu        sync.Mutex
+	peaks     map[string]*Peak
+	order     []string
+	sparkline *SparklineTracker
+	palette   Palette
+	glyphs    GlyphMode
+}
+
+// NewPeakTracker creates a peak tracker keeping maxLen values for trends.
+func NewPeakTracker(maxLen int) *PeakTracker {
+	return &PeakTracker{
+		peaks:     make(map[string]*Peak),
+		sparkline: NewSparklineTracker(maxLen),
+		palette:   PaletteDefault,
+	}
+}
+
+// SetGlyphs marks statuses in RenderPeaks as Formatter.SetGlyphs does.
+func (p *PeakTracker) SetGlyphs(mode GlyphMode) {
+	p.glyphs = mode
+}
+
+// SetPalette selects the status color palette for RenderPeaks.
+func (p *PeakTracker) SetPalette(palette Palette) {
+	p.palette = palette
+}
+
+// Record folds a sample taken at the given time into the peaks.
+// Checks with unknown status are ignored.
+func (p *PeakTracker) Record(checks []use.Check, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, c := range checks {
+		if c.Status == use.StatusUnknown {
+			continue
+		}
+		key := c.Key()
+		p.sparkline.Record(key, c.RawValue)
+
+		peak, ok := p.peaks[key]
+		if !ok {
+			p.peaks[key] = &Peak{Check: c, At: at, Samples: 1}
+			p.order = append(p.order, key)
+			continue
+		}
+		peak.Samples++
+		if c.RawValue > peak.Check.RawValue {
+			peak.Check = c
+			peak.At = at
+		}
+	}
+}
+
+// Peaks returns the peak per check in first-seen order.
+func (p *PeakTracker) Peaks() []Peak {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]Peak, 0, len(p.order))
+	for _, key := range p.order {
+		result = append(result, *p.peaks[key])
+	}
+	return result
+}
+
+// RenderPeaks outputs a peak-oriented report for a sampling window.
+func (p *PeakTracker) RenderPeaks(w io.Writer, window time.Duration) {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("62")).Padding(0, 1)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	statusStyles := StatusStyles(p.palette)
+
+	fmt.Fprintln(w, title.Render(fmt.Sprintf("Peak Values (window %s)", window)))
+	fmt.Fprintln(w, dim.Render(strings.Repeat("═", 90)))
+	fmt.Fprintf(w, "  %s %s %s %s %s %s\n",
+		header.Render("RESOURCE                "),
+		header.Render("TYPE          "),
+		header.Render("PEAK               "),
+		header.Render("STATUS   "),
+		header.Render("AT      "),
+		header.Render("TREND"))
+	fmt.Fprintln(w, "  "+dim.Render(strings.Repeat("─", 90)))
+
+	for _, peak := range p.Peaks() {
+		c := peak.Check
+		key := c.Key()
+		status := statusStyles[c.Status].Render(fmt.Sprintf("%-9s", StatusLabel(c.Status, p.glyphs)))
+		fmt.Fprintf(w, "  %-25s %-15s %-20s %s %-9s %s\n",
+			c.Resource, c.Type, c.Value, status,
+			peak.At.Format("15:04:05"), p.sparkline.Sparkline(key))
+	}
+}
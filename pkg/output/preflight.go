@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// RenderPreflight reports which collectors will be degraded by insufficient
+// permissions, so users know whether to re-run with sudo.
+func RenderPreflight(w io.Writer, accesses []use.Access) {
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	degradedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	fmt.Fprintln(w, title.Render("Privilege Preflight"))
+	fmt.Fprintln(w, dim.Render(strings.Repeat("═", 60)))
+
+	degraded, denied := 0, 0
+	for _, a := range accesses {
+		if a.Accessible {
+			fmt.Fprintf(w, "  [%s] %-12s %s\n", okStyle.Render(" OK "), a.Collector, a.Source)
+			continue
+		}
+		degraded++
+		if a.Reason == use.ReasonPermission {
+			denied++
+		}
+		fmt.Fprintf(w, "  [%s] %-12s %s (%s)\n", degradedStyle.Render("DEGR"), a.Collector, a.Source, a.Reason)
+		fmt.Fprintf(w, "         %s\n", dim.Render(a.Impact))
+	}
+
+	fmt.Fprintln(w)
+	switch {
+	case degraded == 0:
+		fmt.Fprintln(w, okStyle.Render("All data sources accessible."))
+	case denied > 0:
+		fmt.Fprintln(w, degradedStyle.Render(fmt.Sprintf("%d data source(s) need elevated privileges; re-run with sudo for full data.", denied)))
+	default:
+		fmt.Fprintln(w, degradedStyle.Render(fmt.Sprintf("%d data source(s) not available on this host.", degraded)))
+	}
+}
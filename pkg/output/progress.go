@@ -0,0 +1,50 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// spinnerFrames are plain ASCII so progress output stays ANSI-free.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// RenderProgressive prints each collector's checks as soon as it completes,
+// followed by the collectors still running. Output is plain text with no ANSI
+// escapes, so it is safe for pipes and dumb terminals. It returns all checks
+// once every collector has finished, for a final sorted render.
+func RenderProgressive(w io.Writer, results <-chan use.Result, names []string) []use.Check {
+	pending := make(map[string]bool, len(names))
+	for _, n := range names {
+		pending[n] = true
+	}
+
+	var all []use.Check
+	frame := 0
+	for r := range results {
+		delete(pending, r.Collector)
+		all = append(all, r.Checks...)
+
+		done := len(names) - len(pending)
+		fmt.Fprintf(w, "[%d/%d] %s (%s)\n", done, len(names), r.Collector, r.Elapsed.Round(time.Millisecond))
+		for _, c := range r.Checks {
+			fmt.Fprintf(w, "  %-25s %-15s %-20s %s\n", c.Resource, c.Type, c.Value, strings.ToUpper(string(c.Status)))
+		}
+
+		if len(pending) > 0 {
+			running := make([]string, 0, len(pending))
+			for n := range pending {
+				running = append(running, n)
+			}
+			sort.Strings(running)
+			fmt.Fprintf(w, "%s still running: %s\n", spinnerFrames[frame%len(spinnerFrames)], strings.Join(running, ", "))
+			frame++
+		}
+	}
+	fmt.Fprintln(w)
+	return all
+}
@@ -0,0 +1,183 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// promFamilies are the metric families emitted per USE type, in output order.
+var promFamilies = []struct {
+	Type use.MetricType
+	Name string
+	Help string
+}{
+	{use.Utilization, "umd_utilization", "USE utilization per resource, in the check's unit."},
+	{use.Saturation, "umd_saturation", "USE saturation per resource, in the check's unit."},
+	{use.Errors, "umd_errors", "USE errors per resource, in the check's unit."},
+}
+
+// promStatusValues maps check status to the umd_status gauge value.
+var promStatusValues = map[use.Status]int{
+	use.StatusOK:      0,
+	use.StatusWarning: 1,
+	use.StatusError:   2,
+	use.StatusUnknown: 3,
+}
+
+// omUnits maps check units to OpenMetrics base units and the factor that
+// converts values into them, e.g. 42.5 percent -> 0.425 ratio. Counts and
+// unitless checks get no unit suffix.
+var omUnits = map[use.Unit]struct {
+	Name  string
+	Scale float64
+}{
+	use.UnitPercent:        {"ratio", 0.01},
+	use.UnitRatio:          {"ratio", 1},
+	use.UnitBytes:          {"bytes", 1},
+	use.UnitBytesPerSecond: {"bytes_per_second", 1},
+	use.UnitPerSecond:      {"per_second", 1},
+	use.UnitPerMinute:      {"per_second", 1.0 / 60},
+	use.UnitCelsius:        {"celsius", 1},
+}
+
+var promLabelInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// promLabel sanitizes a resource name into a stable label value, e.g.
+// "Disk (sda)" -> "disk_sda" and "Filesystem (/)" -> "filesystem_root".
+func promLabel(resource string) string {
+	s := strings.ReplaceAll(strings.ToLower(resource), "(/)", "(root)")
+	return strings.Trim(promLabelInvalid.ReplaceAllString(s, "_"), "_")
+}
+
+// MetricLabel sanitizes a resource name the way the Prometheus and Nagios
+// formats do, for emitters that name metrics outside this package.
+func MetricLabel(resource string) string {
+	return promLabel(resource)
+}
+
+// renderPrometheus outputs checks in the Prometheus text exposition format:
+// one gauge family per USE type plus a status gauge (0=ok, 1=warning,
+// 2=error, 3=unknown). Unknown checks have no value and only appear in
+// umd_status. Checks with a Kind, such as inode usage next to capacity on
+// the same filesystem, get a kind label. A series reported twice keeps its
+// first value, since duplicate series fail the scrape.
+func (f *Formatter) renderPrometheus(checks []use.Check) error {
+	unique := promSeries(checks)
+	if f.openMetrics {
+		return f.renderOpenMetrics(unique)
+	}
+
+	for _, fam := range promFamilies {
+		fmt.Fprintf(f.writer, "# HELP %s %s\n# TYPE %s gauge\n", fam.Name, fam.Help, fam.Name)
+		for _, s := range unique {
+			if s.check.Type != fam.Type || s.check.Status == use.StatusUnknown {
+				continue
+			}
+			fmt.Fprintf(f.writer, "%s{%s,unit=%q} %s\n",
+				fam.Name, s.labels(), s.check.Unit, strconv.FormatFloat(s.check.RawValue, 'g', -1, 64))
+		}
+	}
+
+	fmt.Fprintln(f.writer, "# HELP umd_status Check status: 0=ok, 1=warning, 2=error, 3=unknown.")
+	fmt.Fprintln(f.writer, "# TYPE umd_status gauge")
+	for _, s := range unique {
+		fmt.Fprintf(f.writer, "umd_status{%s} %d\n", s.labels(), promStatusValues[s.check.Status])
+	}
+	return nil
+}
+
+// promSample is one series with its sanitized resource label.
+type promSample struct {
+	check use.Check
+	label string
+}
+
+// labels returns the series' resource and type labels, plus kind when the
+// check has one.
+func (s promSample) labels() string {
+	l := fmt.Sprintf("resource=%q,type=%q", s.label, s.check.Type)
+	if s.check.Kind != "" {
+		l += fmt.Sprintf(",kind=%q", s.check.Kind)
+	}
+	return l
+}
+
+// promSeries returns the checks to expose, keeping the first of any
+// resource/type/kind series reported twice.
+func promSeries(checks []use.Check) []promSample {
+	seen := make(map[string]bool)
+	var unique []promSample
+	for _, c := range checks {
+		label := promLabel(c.Resource)
+		key := label + "|" + string(c.Type) + "|" + c.Kind
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, promSample{check: c, label: label})
+	}
+	return unique
+}
+
+// renderOpenMetrics outputs checks in the OpenMetrics text format. A family
+// must share one unit, named by its suffix and a UNIT line, so each USE type
+// is split per unit (umd_utilization_ratio, umd_saturation_bytes, ...) with
+// values converted to base units, e.g. 42.5% becomes 0.425. Counts form the
+// unsuffixed family. The exposition ends with the required # EOF.
+func (f *Formatter) renderOpenMetrics(unique []promSample) error {
+	for _, fam := range promFamilies {
+		byUnit := make(map[string][]promSample)
+		for _, s := range unique {
+			if s.check.Type != fam.Type || s.check.Status == use.StatusUnknown {
+				continue
+			}
+			unit := omUnits[s.check.Unit].Name
+			byUnit[unit] = append(byUnit[unit], s)
+		}
+		units := make([]string, 0, len(byUnit))
+		for unit := range byUnit {
+			units = append(units, unit)
+		}
+		sort.Strings(units)
+
+		for _, unit := range units {
+			name := fam.Name
+			if unit != "" {
+				name += "_" + unit
+			}
+			fmt.Fprintf(f.writer, "# TYPE %s gauge\n", name)
+			if unit != "" {
+				fmt.Fprintf(f.writer, "# UNIT %s %s\n", name, unit)
+			}
+			fmt.Fprintf(f.writer, "# HELP %s %s\n", name, strings.Replace(fam.Help, "in the check's unit", "in "+omUnitHelp(unit), 1))
+			for _, s := range byUnit[unit] {
+				value := s.check.RawValue
+				if u, ok := omUnits[s.check.Unit]; ok {
+					value *= u.Scale
+				}
+				fmt.Fprintf(f.writer, "%s{%s} %s\n", name, s.labels(), strconv.FormatFloat(value, 'g', -1, 64))
+			}
+		}
+	}
+
+	fmt.Fprintln(f.writer, "# TYPE umd_status gauge")
+	fmt.Fprintln(f.writer, "# HELP umd_status Check status: 0=ok, 1=warning, 2=error, 3=unknown.")
+	for _, s := range unique {
+		fmt.Fprintf(f.writer, "umd_status{%s} %d\n", s.labels(), promStatusValues[s.check.Status])
+	}
+	fmt.Fprintln(f.writer, "# EOF")
+	return nil
+}
+
+// omUnitHelp describes an OpenMetrics unit for HELP text.
+func omUnitHelp(unit string) string {
+	if unit == "" {
+		return "counts"
+	}
+	return strings.ReplaceAll(unit, "_", " ")
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/cache"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// DefaultPromptMaxAge is how old a cached run may be before
+// RenderCachedPrompt collects afresh.
+const DefaultPromptMaxAge = 5 * time.Minute
+
+// promptLabels maps resource names to short prompt labels.
+var promptLabels = map[string]string{
+	"CPU":        "CPU",
+	"Memory":     "MEM",
+	"Disk":       "DSK",
+	"Network":    "NET",
+	"Scheduler":  "SCH",
+	"TCP":        "TCP",
+	"VMem":       "VM",
+	"Filesystem": "FS",
+}
+
+// promptLabel returns the short label for a resource like "Disk (sda)".
+func promptLabel(resource string) string {
+	base := resource
+	if idx := strings.Index(base, " ("); idx > 0 {
+		base = base[:idx]
+	}
+	if label, ok := promptLabels[base]; ok {
+		return label
+	}
+	return strings.ToUpper(base)
+}
+
+// promptEntry returns the prompt text for a check: the label with the
+// percentage for percent metrics ("CPU89"), or just the label for rates,
+// bytes and counts, whose raw numbers are too wide for a prompt.
+func promptEntry(label string, c use.Check) string {
+	if c.Unit == use.UnitPercent {
+		return fmt.Sprintf("%s%.0f", label, c.RawValue)
+	}
+	return label
+}
+
+// RenderPrompt writes a compact one-line status for shell prompts and status
+// bars, e.g. "⚡CPU89 MEM72 TCP", or a green "✓" when all checks are OK.
+// Only percentages carry a number. Only the worst check per resource label is shown, and entries that would
+// exceed maxWidth visible characters are dropped (0 disables truncation).
+func RenderPrompt(w io.Writer, checks []use.Check, maxWidth int) error {
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+
+	issues := use.RankIssues(checks)
+	if len(issues) == 0 {
+		_, err := fmt.Fprintln(w, okStyle.Render("✓"))
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var parts []string
+	width := 1 // leading glyph
+	for _, c := range issues {
+		label := promptLabel(c.Resource)
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+
+		entry := promptEntry(label, c)
+		sep := 0
+		if len(parts) > 0 {
+			sep = 1
+		}
+		if maxWidth > 0 && width+sep+len(entry) > maxWidth {
+			break
+		}
+		width += sep + len(entry)
+
+		style := warnStyle
+		if c.Status == use.StatusError {
+			style = errStyle
+		}
+		parts = append(parts, style.Render(entry))
+	}
+
+	glyphStyle := warnStyle
+	if use.OverallStatus(checks) == use.StatusError {
+		glyphStyle = errStyle
+	}
+	_, err := fmt.Fprintln(w, glyphStyle.Render("⚡")+strings.Join(parts, " "))
+	return err
+}
+
+// RenderCachedPrompt renders the prompt from the last-run cache at path
+// (cache.DefaultPath when empty), so a prompt render costs a file read rather
+// than a collection. When the cache is missing or older than maxAge, refresh
+// is called for fresh checks instead; a Checker recording with
+// cache.Recorder also rewrites the cache for the next render. With a nil
+// refresh, a stale run is still rendered and only a missing cache is an
+// error.
+func RenderCachedPrompt(w io.Writer, path string, maxAge time.Duration, maxWidth int, refresh func() []use.Check) error {
+	run, err := cache.Load(path, maxAge)
+	switch {
+	case err == nil:
+		return RenderPrompt(w, run.Checks, maxWidth)
+	case refresh != nil:
+		return RenderPrompt(w, refresh(), maxWidth)
+	case errors.Is(err, cache.ErrStale):
+		return RenderPrompt(w, run.Checks, maxWidth)
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// RunbookRule maps matching checks to a runbook URL. Empty fields match any value.
+type RunbookRule struct {
+	Resource string         `json:"resource,omitempty"` // base name, e.g. "Disk" matches "Disk (sda)"
+	Type     use.MetricType `json:"type,omitempty"`
+	Status   use.Status     `json:"status,omitempty"`
+	URL      string         `json:"url"`
+}
+
+// RunbookLink is a resolved runbook for a failing check.
+type RunbookLink struct {
+	Resource string         `json:"resource"`
+	Type     use.MetricType `json:"type"`
+	URL      string         `json:"url"`
+}
+
+// RunbookResolver finds the runbook for a check. The first matching rule wins,
+// so more specific rules should be listed first.
+type RunbookResolver struct {
+	rules []RunbookRule
+}
+
+// NewRunbookResolver creates a resolver from rules.
+func NewRunbookResolver(rules []RunbookRule) *RunbookResolver {
+	return &RunbookResolver{rules: rules}
+}
+
+// LoadRunbooks reads runbook rules from a JSON file containing an array of rules.
+func LoadRunbooks(path string) (*RunbookResolver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read runbooks: %w", err)
+	}
+	var rules []RunbookRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("cannot parse runbooks: %w", err)
+	}
+	return NewRunbookResolver(rules), nil
+}
+
+// Resolve returns the runbook URL for a warning or error check, or "" if none matches.
+func (r *RunbookResolver) Resolve(check use.Check) string {
+	if r == nil || (check.Status != use.StatusWarning && check.Status != use.StatusError) {
+		return ""
+	}
+	base := check.Resource
+	if idx := strings.Index(base, " ("); idx > 0 {
+		base = base[:idx]
+	}
+	for _, rule := range r.rules {
+		if rule.Resource != "" && !strings.EqualFold(rule.Resource, base) && !strings.EqualFold(rule.Resource, check.Resource) {
+			continue
+		}
+		if rule.Type != "" && rule.Type != check.Type {
+			continue
+		}
+		if rule.Status != "" && rule.Status != check.Status {
+			continue
+		}
+		return rule.URL
+	}
+	return ""
+}
+
+// Links returns resolved runbooks for all failing checks.
+func (r *RunbookResolver) Links(checks []use.Check) []RunbookLink {
+	var links []RunbookLink
+	for _, c := range checks {
+		if url := r.Resolve(c); url != "" {
+			links = append(links, RunbookLink{Resource: c.Resource, Type: c.Type, URL: url})
+		}
+	}
+	return links
+}
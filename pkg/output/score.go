@@ -1,27 +1,96 @@
 package output
 
-import "github.com/danpilch/umd/pkg/use"
+import (
+	"math"
+	"sort"
+	"strings"
 
-// HealthScore computes a 0-100 health score from check results.
-// Starts at 100, -15 per critical/error, -5 per warning, -3 per unknown.
-func HealthScore(checks []use.Check) int {
-	score := 100
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// statusPenalty is the points a check deducts from the health score at
+// weight 1.
+var statusPenalty = map[use.Status]float64{
+	use.StatusError:   15,
+	use.StatusWarning: 5,
+	use.StatusUnknown: 3,
+}
+
+// ScoreContribution is one check's deduction from the health score.
+type ScoreContribution struct {
+	Resource string         `json:"resource"`
+	Type     use.MetricType `json:"type"`
+	Status   use.Status     `json:"status"`
+	Weight   float64        `json:"weight"`
+	Points   float64        `json:"points"`
+}
+
+// ScoreWeights scales each check's deduction, so a critical disk can count
+// for more than a noisy TCP counter. Keys are looked up like saturation
+// limits: "Disk (sda)|saturation", then "Disk (sda)", then the base
+// resource "Disk". Unmatched checks weigh 1; a weight of 0 ignores them.
+type ScoreWeights map[string]float64
+
+// weight returns the weight for a check.
+func (w ScoreWeights) weight(c use.Check) float64 {
+	base, _, _ := strings.Cut(c.Resource, " (")
+	for _, key := range []string{c.Resource + "|" + string(c.Type), c.Resource, base + "|" + string(c.Type), base} {
+		if v, ok := w[key]; ok {
+			return v
+		}
+	}
+	return 1
+}
+
+// Score computes a 0-100 health score with these weights, returning the
+// deductions behind it, largest first.
+func (w ScoreWeights) Score(checks []use.Check) (int, []ScoreContribution) {
+	var (
+		total    float64
+		deducted []ScoreContribution
+	)
 	for _, c := range checks {
-		switch c.Status {
-		case use.StatusError:
-			score -= 15
-		case use.StatusWarning:
-			score -= 5
-		case use.StatusUnknown:
-			score -= 3
+		penalty, ok := statusPenalty[c.Status]
+		if !ok {
+			continue
+		}
+		weight := w.weight(c)
+		if weight <= 0 {
+			continue
 		}
+		points := penalty * weight
+		total += points
+		deducted = append(deducted, ScoreContribution{
+			Resource: c.Resource,
+			Type:     c.Type,
+			Status:   c.Status,
+			Weight:   weight,
+			Points:   points,
+		})
 	}
+	sort.SliceStable(deducted, func(i, j int) bool {
+		return deducted[i].Points > deducted[j].Points
+	})
+	score := int(math.Round(100 - total))
 	if score < 0 {
 		score = 0
 	}
+	return score, deducted
+}
+
+// HealthScore computes a 0-100 health score from check results.
+// Starts at 100, -15 per critical/error, -5 per warning, -3 per unknown.
+func HealthScore(checks []use.Check) int {
+	score, _ := HealthScoreDetailed(checks)
 	return score
 }
 
+// HealthScoreDetailed computes the unweighted health score along with the
+// checks that lowered it and by how much. See ScoreWeights to weight them.
+func HealthScoreDetailed(checks []use.Check) (int, []ScoreContribution) {
+	return ScoreWeights(nil).Score(checks)
+}
+
 // ScoreLabel returns a human-readable label for a health score.
 func ScoreLabel(score int) string {
 	if score >= 80 {
@@ -1,26 +1,69 @@
 package output
 
 import (
+	"math"
 	"strings"
 	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/use"
 )
 
 // SparklineTracker keeps a rolling window of metric values for sparkline rendering.
 type SparklineTracker struct {
-	mu     sync.Mutex
-	data   map[string][]float64
-	maxLen int
+	mu      sync.Mutex
+	data    map[string][]float64
+	maxLen  int
+	horizon int // recency horizon in samples; 0 scales to the whole window
+	style   SparkStyle
+}
+
+// SparkStyle selects how sparklines are drawn.
+type SparkStyle string
+
+const (
+	// SparkBlocks draws one value per character using 8 block heights.
+	SparkBlocks SparkStyle = "blocks"
+	// SparkBraille packs two values per braille character, 4 dot heights
+	// each, fitting twice the history into the same width.
+	SparkBraille SparkStyle = "braille"
+)
+
+// SparklineOption configures a SparklineTracker.
+type SparklineOption func(*SparklineTracker)
+
+// WithRecencyHorizon scales sparklines mainly to the most recent n samples.
+// Older samples still render, but their influence on the min/max range
+// halves for every further n/4 samples of age, so an early spike doesn't
+// flatten recent variation into a single block level.
+func WithRecencyHorizon(n int) SparklineOption {
+	return func(s *SparklineTracker) {
+		if n > 0 {
+			s.horizon = n
+		}
+	}
+}
+
+// WithSparkStyle selects block (default) or braille sparklines.
+func WithSparkStyle(style SparkStyle) SparklineOption {
+	return func(s *SparklineTracker) {
+		s.style = style
+	}
 }
 
 // NewSparklineTracker creates a tracker with a fixed window size.
-func NewSparklineTracker(maxLen int) *SparklineTracker {
+func NewSparklineTracker(maxLen int, opts ...SparklineOption) *SparklineTracker {
 	if maxLen < 1 {
 		maxLen = 20
 	}
-	return &SparklineTracker{
+	s := &SparklineTracker{
 		data:   make(map[string][]float64),
 		maxLen: maxLen,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Record adds a new value for a metric key.
@@ -39,12 +82,83 @@ func (s *SparklineTracker) Sparkline(key string) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	values, ok := s.data[key]
-	if !ok || len(values) == 0 {
+	return s.render(s.data[key])
+}
+
+// SparklineColored returns the sparkline for a metric key with each
+// character colored by the status its value would have had, so a trend
+// climbing into the warning or error range stands out. Keys are Check.Key
+// values as recorded by the formatter; any "|Kind" suffix is ignored when
+// picking thresholds. Colors follow lipgloss's
+// detection for stdout and are dropped when it isn't a terminal.
+func (s *SparklineTracker) SparklineColored(key string, thresholds use.Thresholds) string {
+	return s.sparklineStyled(key, thresholds, StatusStyles(PaletteDefault))
+}
+
+// sparklineStyled renders a sparkline with each character styled by its
+// value's status; for braille, the worse of the character's two values.
+func (s *SparklineTracker) sparklineStyled(key string, thresholds use.Thresholds, styles map[use.Status]lipgloss.Style) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := s.data[key]
+	plain := s.render(values)
+	if plain == "" {
+		return ""
+	}
+	resource, mtype := keyMetric(key)
+	perCell := 1
+	if s.style == SparkBraille {
+		perCell = 2
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(plain) {
+		status := use.StatusOK
+		for _, v := range values[i*perCell : min(len(values), (i+1)*perCell)] {
+			if vs := valueStatus(thresholds, resource, mtype, v); vs.Worse(status) {
+				status = vs
+			}
+		}
+		b.WriteString(styles[status].Render(string(r)))
+	}
+	return b.String()
+}
+
+// keyMetric splits a Check.Key into its resource and metric type, dropping
+// the Kind so "Filesystem (/)|utilization|inodes" is judged as utilization.
+func keyMetric(key string) (string, use.MetricType) {
+	resource, rest, _ := strings.Cut(key, "|")
+	mtype, _, _ := strings.Cut(rest, "|")
+	return resource, use.MetricType(mtype)
+}
+
+// valueStatus evaluates a single recorded value the way its collector would:
+// utilization against the percentage thresholds, anything else against the
+// saturation limits.
+func valueStatus(t use.Thresholds, resource string, mtype use.MetricType, v float64) use.Status {
+	if mtype == use.Utilization {
+		return t.EvaluateUtilizationFor(resource, v)
+	}
+	return t.EvaluateSaturationFor(resource, mtype, v)
+}
+
+// render draws values in the tracker's style; the caller holds s.mu.
+func (s *SparklineTracker) render(values []float64) string {
+	if len(values) == 0 {
 		return ""
 	}
 
-	return renderSparkline(values)
+	var lo, hi float64
+	if s.horizon > 0 && len(values) > s.horizon {
+		lo, hi = recencyRange(values, s.horizon)
+	} else {
+		lo, hi = valueRange(values)
+	}
+	if s.style == SparkBraille {
+		return renderBrailleRange(values, lo, hi)
+	}
+	return renderSparklineRange(values, lo, hi)
 }
 
 // sparkline block characters from lowest to highest
@@ -63,8 +177,12 @@ func renderSparkline(values []float64) string {
 	if len(values) == 0 {
 		return ""
 	}
+	min, max := valueRange(values)
+	return renderSparklineRange(values, min, max)
+}
 
-	// Find min and max
+// valueRange returns the min and max of values, which must be non-empty.
+func valueRange(values []float64) (float64, float64) {
 	min, max := values[0], values[0]
 	for _, v := range values {
 		if v < min {
@@ -74,7 +192,42 @@ func renderSparkline(values []float64) string {
 			max = v
 		}
 	}
+	return min, max
+}
+
+// recencyRange returns the min/max scale for values with samples older than
+// horizon down-weighted: an old sample's distance outside the recent range
+// decays by half per quarter-horizon of additional age.
+func recencyRange(values []float64, horizon int) (float64, float64) {
+	recent := values[len(values)-horizon:]
+	min, max := recent[0], recent[0]
+	for _, v := range recent {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
 
+	halfLife := math.Max(float64(horizon)/4, 1)
+	lo, hi := min, max
+	for i, v := range values[:len(values)-horizon] {
+		age := float64(len(values) - horizon - i)
+		weight := math.Pow(0.5, age/halfLife)
+		if v > max {
+			hi = math.Max(hi, max+(v-max)*weight)
+		}
+		if v < min {
+			lo = math.Min(lo, min-(min-v)*weight)
+		}
+	}
+	return lo, hi
+}
+
+// renderSparklineRange renders values scaled to [min, max]; values outside
+// the range clamp to the lowest or highest block.
+func renderSparklineRange(values []float64, min, max float64) string {
 	var b strings.Builder
 	rng := max - min
 	for _, v := range values {
@@ -93,3 +246,38 @@ func renderSparkline(values []float64) string {
 
 	return b.String()
 }
+
+// brailleDots are the dot bits of the left and right braille columns, from
+// the bottom row up (U+2800 + bits).
+var brailleDots = [2][4]rune{
+	{0x40, 0x04, 0x02, 0x01}, // dots 7, 3, 2, 1
+	{0x80, 0x20, 0x10, 0x08}, // dots 8, 6, 5, 4
+}
+
+// renderBrailleRange renders values as braille bars, two values per
+// character, scaled to [min, max]. Every value lights at least the bottom
+// dot so the minimum stays visible, as with the lowest block.
+func renderBrailleRange(values []float64, min, max float64) string {
+	var b strings.Builder
+	rng := max - min
+	for i := 0; i < len(values); i += 2 {
+		cell := rune(0x2800)
+		for col := 0; col < 2 && i+col < len(values); col++ {
+			height := 1
+			if rng > 0 {
+				height = 1 + int((values[i+col]-min)/rng*3)
+			}
+			if height > 4 {
+				height = 4
+			}
+			if height < 1 {
+				height = 1
+			}
+			for row := 0; row < height; row++ {
+				cell |= brailleDots[col][row]
+			}
+		}
+		b.WriteRune(cell)
+	}
+	return b.String()
+}
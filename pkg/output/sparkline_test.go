@@ -0,0 +1,41 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// markStyles tags each character with its status so tests can read the
+// coloring without a terminal.
+func markStyles() map[use.Status]lipgloss.Style {
+	styles := make(map[use.Status]lipgloss.Style)
+	for _, s := range []use.Status{use.StatusOK, use.StatusWarning, use.StatusError, use.StatusUnknown} {
+		tag := string(s)
+		styles[s] = lipgloss.NewStyle().Transform(func(c string) string {
+			return tag + ":" + c + " "
+		})
+	}
+	return styles
+}
+
+func TestSparklineStyledKindKey(t *testing.T) {
+	check := use.Check{Resource: "Filesystem (/)", Type: use.Utilization, Kind: "inodes"}
+	s := NewSparklineTracker(10)
+	for _, v := range []float64{10, 75, 95} {
+		s.Record(check.Key(), v)
+	}
+
+	got := strings.Fields(s.sparklineStyled(check.Key(), use.DefaultThresholds(), markStyles()))
+	want := []use.Status{use.StatusOK, use.StatusWarning, use.StatusError}
+	if len(got) != len(want) {
+		t.Fatalf("got %d cells %q, want %d", len(got), got, len(want))
+	}
+	for i, cell := range got {
+		if status, _, _ := strings.Cut(cell, ":"); status != string(want[i]) {
+			t.Errorf("cell %d = %q, want status %s", i, cell, want[i])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// ValueFormat controls how check values are rendered from RawValue and Unit.
+type ValueFormat struct {
+	BinaryBytes bool // 1024-based KiB/MiB instead of 1000-based kB/MB
+	Precision   int  // decimal places; negative uses the unit's default
+}
+
+// DefaultValueFormat returns a format that uses unit-specific precision and metric bytes.
+func DefaultValueFormat() ValueFormat {
+	return ValueFormat{Precision: -1}
+}
+
+// defaultPrecision returns the decimal places used for a unit when none is set.
+func defaultPrecision(unit use.Unit) int {
+	switch unit {
+	case use.UnitCount:
+		return 0
+	case use.UnitRatio:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// FormatValue renders a raw value in its unit. Returns "" for checks without a unit.
+func FormatValue(raw float64, unit use.Unit, vf ValueFormat) string {
+	prec := vf.Precision
+	if prec < 0 {
+		prec = defaultPrecision(unit)
+	}
+
+	switch unit {
+	case use.UnitPercent:
+		return fmt.Sprintf("%.*f%%", prec, raw)
+	case use.UnitBytes:
+		return formatScaledBytes(raw, vf.BinaryBytes, prec)
+	case use.UnitBytesPerSecond:
+		return formatScaledBytes(raw, vf.BinaryBytes, prec) + "/s"
+	case use.UnitPerSecond:
+		return fmt.Sprintf("%.*f/s", prec, raw)
+	case use.UnitPerMinute:
+		return fmt.Sprintf("%.*f/min", prec, raw)
+	case use.UnitCelsius:
+		return fmt.Sprintf("%.*f°C", prec, raw)
+	case use.UnitCount, use.UnitRatio:
+		return fmt.Sprintf("%.*f", prec, raw)
+	}
+	return ""
+}
+
+// formatScaledBytes formats bytes with metric (kB, MB) or binary (KiB, MiB) prefixes.
+func formatScaledBytes(b float64, binary bool, prec int) string {
+	unit := 1000.0
+	if binary {
+		unit = 1024.0
+	}
+	if b < unit {
+		return fmt.Sprintf("%.0f B", b)
+	}
+	div, exp := unit, 0
+	for n := b / unit; n >= unit && exp < 5; n /= unit {
+		div *= unit
+		exp++
+	}
+	if binary {
+		return fmt.Sprintf("%.*f %ciB", prec, b/div, "KMGTPE"[exp])
+	}
+	return fmt.Sprintf("%.*f %cB", prec, b/div, "kMGTPE"[exp])
+}
+
+// applyValueFormat returns copies of checks with Value re-rendered from
+// RawValue, Unit and Label. Only checks marked ShowsRaw are re-rendered;
+// the rest, and checks with unknown status, keep their Value. A fixed
+// precision also rounds RawValue, so every format emits the same digits.
+func applyValueFormat(checks []use.Check, vf ValueFormat) []use.Check {
+	out := RoundRawValues(checks, vf.Precision)
+	for i, c := range out {
+		if !c.ShowsRaw || c.Status == use.StatusUnknown {
+			continue
+		}
+		if v := renderValue(c, vf); v != "" {
+			out[i].Value = v
+		}
+	}
+	return out
+}
+
+// renderValue renders c's RawValue in its unit followed by its Label. A
+// rate whose label names the unit ("120 csw/s", "3.5 tps") gets a bare
+// number. Returns "" for checks without a unit.
+func renderValue(c use.Check, vf ValueFormat) string {
+	if c.Label != "" && (c.Unit == use.UnitPerSecond || c.Unit == use.UnitPerMinute) {
+		prec := vf.Precision
+		if prec < 0 {
+			prec = defaultPrecision(c.Unit)
+		}
+		return fmt.Sprintf("%.*f %s", prec, c.RawValue, c.Label)
+	}
+	v := FormatValue(c.RawValue, c.Unit, vf)
+	if v != "" && c.Label != "" {
+		v += " " + c.Label
+	}
+	return v
+}
+
+// RoundRawValues returns copies of checks with RawValue rounded to precision
+// decimal places, dropping insignificant digits that make output and baseline
+// comparisons noisy. A negative precision leaves values unchanged.
+func RoundRawValues(checks []use.Check, precision int) []use.Check {
+	out := make([]use.Check, len(checks))
+	copy(out, checks)
+	if precision < 0 {
+		return out
+	}
+	scale := math.Pow(10, float64(precision))
+	for i := range out {
+		out[i].RawValue = math.Round(out[i].RawValue*scale) / scale
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// renderYAML outputs the JSON document as YAML. The document is marshaled to
+// JSON first, so keys, field order and omitempty behaviour match -f json.
+// Parsing that JSON into a yaml.Node keeps the field order, which a map
+// would lose.
+func (f *Formatter) renderYAML(checks []use.Check) error {
+	data, err := json.Marshal(f.document(checks))
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+
+	fmt.Fprintln(f.writer, "---")
+	enc := yaml.NewEncoder(f.writer)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle clears the flow and quoting styles JSON parses with, so the
+// encoder writes block collections and quotes scalars only where needed.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
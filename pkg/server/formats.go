@@ -0,0 +1,51 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/danpilch/umd/pkg/output"
+)
+
+// formatRoute serves the shared cached checks in one output format.
+type formatRoute struct {
+	Format      output.Format
+	ContentType string
+}
+
+// formatRoutes maps paths to formats so different consumers (Prometheus, a
+// dashboard, a shell script) read the same collection in their own format.
+var formatRoutes = map[string]formatRoute{
+	"/metrics":     {output.FormatPrometheus, "text/plain; version=0.0.4; charset=utf-8"},
+	"/checks.json": {output.FormatJSON, "application/json"},
+	"/checks.tsv":  {output.FormatTSV, "text/tab-separated-values; charset=utf-8"},
+	"/checks.csv":  {output.FormatCSV, "text/csv; charset=utf-8"},
+}
+
+// openMetricsContentType is served on /metrics when Options.UseOpenMetrics
+// is set and the scraper asks for OpenMetrics, as Prometheus does by default.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// handleFormat renders the latest checks in the route's format. Output is
+// buffered so a render error returns 500 rather than a truncated body.
+func (s *Server) handleFormat(route formatRoute) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checks, _ := s.Checks()
+
+		var buf bytes.Buffer
+		f := output.NewFormatter(route.Format, &buf)
+		contentType := route.ContentType
+		if route.Format == output.FormatPrometheus && s.opts.UseOpenMetrics && strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text") {
+			f.SetOpenMetrics(true)
+			contentType = openMetricsContentType
+		}
+		if err := f.Render(checks); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// healthResponse is the deliberately small /health body.
+type healthResponse struct {
+	Status    use.Status `json:"status"`
+	Healthy   bool       `json:"healthy"`
+	Issues    int        `json:"issues"`
+	CheckedAt time.Time  `json:"checked_at"`
+}
+
+// handleHealth returns 200 when the overall status is acceptable and 503 when
+// it is one of the configured FailOn statuses.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	checks, at := s.Checks()
+	overall := use.OverallStatus(checks)
+
+	resp := healthResponse{
+		Status:    overall,
+		Healthy:   !s.fails(overall),
+		Issues:    len(use.RankIssues(checks)),
+		CheckedAt: at,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if !resp.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) fails(status use.Status) bool {
+	for _, f := range s.opts.FailOn {
+		if f == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Package server exposes umd results over HTTP for probes and scrapers.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Options configures the HTTP server.
+type Options struct {
+	Addr string
+
+	// MinInterval is the minimum time between collections; requests within
+	// it are served from the previous results so probes don't hammer the host.
+	MinInterval time.Duration
+
+	// FailOn lists the overall statuses for which /health returns 503.
+	FailOn []use.Status
+
+	// UseOpenMetrics serves OpenMetrics on /metrics to scrapers that accept
+	// it. Off by default: Prometheus asks for OpenMetrics out of the box,
+	// and its families use base units (umd_utilization_ratio, 0-1) where
+	// the text format has umd_utilization in percent, so enabling it
+	// changes the series existing dashboards and alerts query.
+	UseOpenMetrics bool
+}
+
+// DefaultOptions returns sensible defaults.
+func DefaultOptions() Options {
+	return Options{
+		Addr:        ":9110",
+		MinInterval: 10 * time.Second,
+		FailOn:      []use.Status{use.StatusError, use.StatusUnknown},
+	}
+}
+
+// Server collects checks on demand and serves them over HTTP.
+type Server struct {
+	checker    *use.Checker
+	collectors []use.Collector
+	opts       Options
+
+	mu          sync.Mutex
+	checks      []use.Check
+	collectedAt time.Time
+}
+
+// New creates a server that runs the given collectors.
+func New(checker *use.Checker, collectors []use.Collector, opts Options) *Server {
+	return &Server{
+		checker:    checker,
+		collectors: collectors,
+		opts:       opts,
+	}
+}
+
+// Checks returns the latest results, collecting first if they are older
+// than MinInterval. Concurrent callers share a single collection.
+func (s *Server) Checks() ([]use.Check, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checks == nil || time.Since(s.collectedAt) >= s.opts.MinInterval {
+		s.checks = s.checker.RunAll(s.collectors)
+		s.collectedAt = time.Now()
+	}
+	return s.checks, s.collectedAt
+}
+
+// Handler returns the HTTP handler with all endpoints registered.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	for path, route := range formatRoutes {
+		mux.HandleFunc(path, s.handleFormat(route))
+	}
+	return mux
+}
+
+// Start serves HTTP in the background. Returns a stop function to gracefully
+// shut down the server.
+func (s *Server) Start() (func(), error) {
+	server := &http.Server{
+		Addr:              s.opts.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	// Give the server a moment to start and check for immediate errors
+	select {
+	case err := <-errCh:
+		return nil, fmt.Errorf("server failed: %w", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stop := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}
+
+	return stop, nil
+}
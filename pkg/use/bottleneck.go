@@ -0,0 +1,106 @@
+package use
+
+import "strings"
+
+// Bottleneck classes reported by ClassifyBottleneck.
+const (
+	BottleneckCPU     = "CPU"
+	BottleneckMemory  = "memory"
+	BottleneckIO      = "I/O"
+	BottleneckNetwork = "network"
+)
+
+// bottleneckClasses maps a collector's base resource name to its class.
+var bottleneckClasses = map[string]string{
+	"cpu":             BottleneckCPU,
+	"scheduler":       BottleneckCPU,
+	"irq":             BottleneckCPU,
+	"memory":          BottleneckMemory,
+	"vmem":            BottleneckMemory,
+	"membw":           BottleneckMemory,
+	"numa":            BottleneckMemory,
+	"disk":            BottleneckIO,
+	"filesystem":      BottleneckIO,
+	"network":         BottleneckNetwork,
+	"tcp":             BottleneckNetwork,
+	"psi (cpu)":       BottleneckCPU,
+	"psi (memory)":    BottleneckMemory,
+	"psi (io)":        BottleneckIO,
+	"cgroup (cpu)":    BottleneckCPU,
+	"cgroup (memory)": BottleneckMemory,
+	"cgroup (io)":     BottleneckIO,
+}
+
+// minBottleneckScore is the relative saturation below which no resource is
+// considered a bottleneck.
+const minBottleneckScore = 0.5
+
+// BottleneckClass returns the bottleneck class for a check's resource, e.g.
+// "Disk (sda)" -> "I/O", or "" if the resource isn't classified. Resources
+// such as "PSI (io)" are classified by their full name.
+func BottleneckClass(resource string) string {
+	if class, ok := bottleneckClasses[strings.ToLower(resource)]; ok {
+		return class
+	}
+	base := resource
+	if i := strings.Index(base, " ("); i >= 0 {
+		base = base[:i]
+	}
+	return bottleneckClasses[strings.ToLower(base)]
+}
+
+// SaturationScore normalizes a saturation check so different resources can be
+// compared: 1.0 means saturated. Ratios (load/CPU) and percentages are used
+// directly; other units only count through their status.
+func SaturationScore(c Check) float64 {
+	if c.Type != Saturation {
+		return 0
+	}
+	var score float64
+	switch c.Unit {
+	case UnitRatio:
+		score = c.RawValue
+	case UnitPercent:
+		score = c.RawValue / 100
+	}
+	switch c.Status {
+	case StatusError:
+		score = max(score, 1.0)
+	case StatusWarning:
+		score = max(score, 0.7)
+	}
+	return score
+}
+
+// ClassifyBottleneck names the likely bottleneck by comparing normalized
+// saturation across resources; the highest relative saturation wins. It
+// returns "" when nothing is meaningfully saturated. Confidence (0-1) grows
+// with the winner's saturation and its margin over the runner-up.
+func ClassifyBottleneck(checks []Check) (resource string, confidence float64) {
+	scores := make(map[string]float64)
+	for _, c := range checks {
+		class := BottleneckClass(c.Resource)
+		if class == "" {
+			continue
+		}
+		if s := SaturationScore(c); s > scores[class] {
+			scores[class] = s
+		}
+	}
+
+	var top, second float64
+	for _, class := range []string{BottleneckCPU, BottleneckMemory, BottleneckIO, BottleneckNetwork} {
+		s := scores[class]
+		switch {
+		case s > top:
+			second = top
+			top, resource = s, class
+		case s > second:
+			second = s
+		}
+	}
+	if top < minBottleneckScore {
+		return "", 0
+	}
+	return resource, min(top, 1.0) * (top - second) / top
+}
@@ -1,15 +1,52 @@
 package use
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// DefaultCollectorTimeout bounds each collector's run when no timeout is set.
+// It is generous: collectors sample for at most a second or two, so hitting
+// it means a wedged source (a hung dmesg or `log show`), not a slow one.
+const DefaultCollectorTimeout = 30 * time.Second
+
 // Checker orchestrates the collection of USE metrics from multiple collectors.
 type Checker struct {
 	thresholds Thresholds
 	logger     *logrus.Logger
+	tracer     Tracer
+	counter    CounterMode
+	timeout    time.Duration
+	recorder   Recorder
+}
+
+// Recorder persists the checks of each completed RunAll, so every run and
+// watch tick feeds trend history. history.Recorder and cache.Recorder
+// implement it.
+type Recorder interface {
+	Record(checks []Check, at time.Time) error
+}
+
+// Recorders records each run with every recorder in turn, e.g. both the
+// history file and the last-run cache. A failing recorder doesn't stop the
+// others; their errors are joined.
+type Recorders []Recorder
+
+// Record passes checks to each recorder.
+func (rs Recorders) Record(checks []Check, at time.Time) error {
+	var errs []error
+	for _, r := range rs {
+		if err := r.Record(checks, at); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // Collector interface for resource collectors.
@@ -18,6 +55,22 @@ type Collector interface {
 	Collect(thresholds Thresholds) ([]Check, error)
 }
 
+// ContextCollector is implemented by collectors that can stop early when
+// ctx is done, typically by running their commands with exec.CommandContext
+// so a hung `log show` or `netstat` is killed rather than leaked.
+type ContextCollector interface {
+	CollectContext(ctx context.Context, thresholds Thresholds) ([]Check, error)
+}
+
+// CollectContext runs col with ctx when it implements ContextCollector and
+// falls back to Collect otherwise, so every Collector can be called this way.
+func CollectContext(ctx context.Context, col Collector, thresholds Thresholds) ([]Check, error) {
+	if cc, ok := col.(ContextCollector); ok {
+		return cc.CollectContext(ctx, thresholds)
+	}
+	return col.Collect(thresholds)
+}
+
 // NewChecker creates a new USE method checker.
 func NewChecker(thresholds Thresholds, logger *logrus.Logger) *Checker {
 	if logger == nil {
@@ -30,53 +83,229 @@ func NewChecker(thresholds Thresholds, logger *logrus.Logger) *Checker {
 	}
 }
 
+// SetTracer passes a tracer to every Traceable collector before it runs.
+func (c *Checker) SetTracer(t Tracer) {
+	c.tracer = t
+}
+
+// SetCounterMode selects rate or cumulative reporting for counter metrics.
+func (c *Checker) SetCounterMode(m CounterMode) {
+	c.counter = m
+}
+
+// SetTimeout sets the per-collector deadline for RunAll and Stream. Zero
+// uses DefaultCollectorTimeout; a negative value disables the deadline.
+func (c *Checker) SetTimeout(d time.Duration) {
+	c.timeout = d
+}
+
+// SetRecorder records the checks of every RunAll and RunAllContext with r.
+// A nil recorder disables recording.
+func (c *Checker) SetRecorder(r Recorder) {
+	c.recorder = r
+}
+
+// collectorTimeout returns the effective per-collector deadline, or zero
+// for none.
+func (c *Checker) collectorTimeout() time.Duration {
+	switch {
+	case c.timeout == 0:
+		return DefaultCollectorTimeout
+	case c.timeout < 0:
+		return 0
+	}
+	return c.timeout
+}
+
+// configure hands the checker's tracer and counter mode to a collector that
+// supports them.
+func (c *Checker) configure(col Collector) {
+	if tc, ok := col.(Traceable); ok && c.tracer != nil {
+		tc.SetTracer(c.tracer)
+	}
+	if cm, ok := col.(CounterModal); ok && c.counter != "" {
+		cm.SetCounterMode(c.counter)
+	}
+}
+
+// Result is the output of a single collector, delivered by Stream.
+type Result struct {
+	Collector string
+	Checks    []Check
+	Elapsed   time.Duration
+}
+
 // RunAll executes all collectors and returns aggregated results.
 func (c *Checker) RunAll(collectors []Collector) []Check {
-	var (
-		allChecks []Check
-		mu        sync.Mutex
-		wg        sync.WaitGroup
-	)
+	return c.RunAllContext(context.Background(), collectors)
+}
+
+// RunAllContext is like RunAll but gives up on collectors still running when
+// ctx is done, reporting them as unknown.
+func (c *Checker) RunAllContext(ctx context.Context, collectors []Collector) []Check {
+	at := time.Now()
+	var allChecks []Check
+	for r := range c.StreamContext(ctx, collectors) {
+		allChecks = append(allChecks, r.Checks...)
+	}
+	if c.recorder != nil {
+		if err := c.recorder.Record(allChecks, at); err != nil {
+			c.logger.WithField("error", err).Warn("Cannot record run")
+		}
+	}
+	return allChecks
+}
+
+// Stream runs all collectors concurrently and delivers each collector's
+// results as soon as it completes. The channel is closed once all are done.
+func (c *Checker) Stream(collectors []Collector) <-chan Result {
+	return c.StreamContext(context.Background(), collectors)
+}
+
+// StreamContext is like Stream but bounds each collector by the checker's
+// timeout and by ctx. A collector that overruns is reported as an unknown
+// check instead of blocking the run.
+func (c *Checker) StreamContext(ctx context.Context, collectors []Collector) <-chan Result {
+	results := make(chan Result, len(collectors))
+	var wg sync.WaitGroup
 
 	for _, collector := range collectors {
+		c.configure(collector)
 		wg.Add(1)
 		go func(col Collector) {
 			defer wg.Done()
+			start := time.Now()
+			checks := c.collectWithin(ctx, col)
+			results <- Result{Collector: col.Name(), Checks: checks, Elapsed: time.Since(start)}
+		}(collector)
+	}
 
-			c.logger.WithField("collector", col.Name()).Debug("Running collector")
-
-			checks, err := col.Collect(c.thresholds)
-			if err != nil {
-				c.logger.WithFields(logrus.Fields{
-					"collector": col.Name(),
-					"error":     err,
-				}).Warn("Collector failed")
-
-				// Add unknown status check for failed collector
-				checks = []Check{{
-					Resource:    col.Name(),
-					Type:        Utilization,
-					Value:       "unknown",
-					RawValue:    0,
-					Status:      StatusUnknown,
-					Description: err.Error(),
-				}}
-			}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
 
-			mu.Lock()
-			allChecks = append(allChecks, checks...)
-			mu.Unlock()
-		}(collector)
+// collectWithin runs one collector, abandoning it once its deadline passes
+// or ctx is done. A ContextCollector is cancelled too; any other collector
+// keeps running in the background until its blocking call returns, and its
+// result is discarded.
+func (c *Checker) collectWithin(ctx context.Context, col Collector) []Check {
+	timeout := c.collectorTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	wg.Wait()
-	return allChecks
+	done := make(chan []Check, 1)
+	go func() {
+		done <- c.collect(ctx, col)
+	}()
+
+	select {
+	case checks := <-done:
+		return checks
+	case <-ctx.Done():
+		reason := fmt.Sprintf("collector cancelled: %v", ctx.Err())
+		if ctx.Err() == context.DeadlineExceeded {
+			reason = fmt.Sprintf("collector timed out after %s", timeout)
+		}
+		c.logger.WithFields(logrus.Fields{
+			"collector": col.Name(),
+			"error":     ctx.Err(),
+		}).Warn("Collector abandoned")
+		return []Check{{
+			Resource:    col.Name(),
+			Type:        Utilization,
+			Value:       "unknown",
+			Status:      StatusUnknown,
+			Description: reason,
+		}}
+	}
+}
+
+// collect runs one collector, converting a failure or panic into an unknown
+// check so one broken collector can't take down a long-running loop.
+func (c *Checker) collect(ctx context.Context, col Collector) (checks []Check) {
+	c.logger.WithField("collector", col.Name()).Debug("Running collector")
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.WithFields(logrus.Fields{
+				"collector": col.Name(),
+				"panic":     r,
+			}).Error("Collector panicked")
+			checks = []Check{{
+				Resource:    col.Name(),
+				Type:        Utilization,
+				Value:       "unknown",
+				Status:      StatusUnknown,
+				Description: fmt.Sprintf("collector panicked: %v", r),
+			}}
+		}
+	}()
+
+	checks, err := CollectContext(ctx, col, c.thresholds.ForResource(col.Name()))
+	if err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"collector": col.Name(),
+			"error":     err,
+		}).Warn("Collector failed")
+
+		// Add unknown status check for failed collector
+		checks = []Check{{
+			Resource:    col.Name(),
+			Type:        Utilization,
+			Value:       "unknown",
+			RawValue:    0,
+			Status:      StatusUnknown,
+			Description: err.Error(),
+		}}
+	}
+	return withCollector(checks, col.Name())
+}
+
+// withCollector returns a copy of checks attributed to the named collector.
+// It copies because a caching collector hands out the same slice each run.
+func withCollector(checks []Check, name string) []Check {
+	if checks == nil {
+		return nil
+	}
+	out := make([]Check, len(checks))
+	for i, c := range checks {
+		c.Collector = name
+		out[i] = c
+	}
+	return out
+}
+
+// RunFor repeatedly runs all collectors every interval until duration has
+// elapsed, passing each sample and its timestamp to fn.
+func (c *Checker) RunFor(collectors []Collector, duration, interval time.Duration, fn func(checks []Check, at time.Time)) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	deadline := time.Now().Add(duration)
+	for {
+		at := time.Now()
+		fn(c.RunAll(collectors), at)
+
+		next := at.Add(interval)
+		if !next.Before(deadline) {
+			return
+		}
+		time.Sleep(time.Until(next))
+	}
 }
 
 // RunOne executes a single collector by name.
 func (c *Checker) RunOne(collector Collector) ([]Check, error) {
 	c.logger.WithField("collector", collector.Name()).Debug("Running collector")
-	return collector.Collect(c.thresholds)
+	c.configure(collector)
+	checks, err := collector.Collect(c.thresholds.ForResource(collector.Name()))
+	return withCollector(checks, collector.Name()), err
 }
 
 // Summary calculates summary statistics from check results.
@@ -106,6 +335,81 @@ func Summarize(checks []Check) Summary {
 	return s
 }
 
+// statusRank orders statuses from healthiest to worst.
+var statusRank = map[Status]int{
+	StatusOK:      0,
+	StatusUnknown: 1,
+	StatusWarning: 2,
+	StatusError:   3,
+}
+
+// StatusRank returns s's position from healthiest to worst: OK, unknown,
+// warning, error. Unrecognized statuses rank with OK.
+func StatusRank(s Status) int {
+	return statusRank[s]
+}
+
+// Worse reports whether s is a worse status than o.
+func (s Status) Worse(o Status) bool {
+	return statusRank[s] > statusRank[o]
+}
+
+// OverallStatus returns the worst status across all checks.
+func OverallStatus(checks []Check) Status {
+	overall := StatusOK
+	for _, check := range checks {
+		if check.Status.Worse(overall) {
+			overall = check.Status
+		}
+	}
+	return overall
+}
+
+// RankIssues returns warning and error checks ordered by severity, then by
+// raw value (highest first).
+func RankIssues(checks []Check) []Check {
+	var issues []Check
+	for _, check := range checks {
+		if check.Status == StatusWarning || check.Status == StatusError {
+			issues = append(issues, check)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Status != issues[j].Status {
+			return issues[i].Status.Worse(issues[j].Status)
+		}
+		return issues[i].RawValue > issues[j].RawValue
+	})
+	return issues
+}
+
+// typeOrder ranks metric types in USE order for SortChecks.
+var typeOrder = map[MetricType]int{
+	Utilization: 0,
+	Saturation:  1,
+	Errors:      2,
+}
+
+// SortChecks orders checks in place by resource name, then by metric type in
+// USE order (utilization, saturation, errors), so output doesn't depend on
+// which collector finished first. Checks sharing both keep their order.
+func SortChecks(checks []Check) {
+	sort.SliceStable(checks, func(i, j int) bool {
+		if checks[i].Resource != checks[j].Resource {
+			return checks[i].Resource < checks[j].Resource
+		}
+		ri, oki := typeOrder[checks[i].Type]
+		rj, okj := typeOrder[checks[j].Type]
+		if oki != okj {
+			return oki // known types before any others
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return checks[i].Type < checks[j].Type
+	})
+}
+
 // ExitCode returns the appropriate exit code based on check results.
 func ExitCode(checks []Check) int {
 	summary := Summarize(checks)
@@ -120,3 +424,30 @@ func ExitCode(checks []Check) int {
 	}
 	return 0 // All OK
 }
+
+// ExitReason explains ExitCode in one logfmt line, for printing to stderr so
+// CI logs show which check failed without re-running with full output:
+//
+//	exit=2 status=error resource="Disk (sda)" type=saturation value="9.00 avgqu"
+//
+// The check is the worst one behind the code, ties going to the highest raw
+// value as in RankIssues. It returns "" when the exit code is 0.
+func ExitReason(checks []Check) string {
+	code := ExitCode(checks)
+	var worst *Check
+	switch code {
+	case 0:
+		return ""
+	case 3:
+		for i := range checks {
+			if checks[i].Status == StatusUnknown {
+				worst = &checks[i]
+				break
+			}
+		}
+	default:
+		worst = &RankIssues(checks)[0]
+	}
+	return fmt.Sprintf("exit=%d status=%s resource=%q type=%s value=%q",
+		code, worst.Status, worst.Resource, worst.Type, worst.Value)
+}
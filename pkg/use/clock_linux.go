@@ -0,0 +1,30 @@
+//go:build linux
+
+package use
+
+import (
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// atClkTck is the auxiliary vector entry holding USER_HZ (AT_CLKTCK in
+// linux/auxvec.h).
+const atClkTck = 17
+
+// ClockTicks returns USER_HZ, the unit of utime and stime in
+// /proc/[pid]/stat. Like sysconf(_SC_CLK_TCK), it reads the value the kernel
+// passes in the auxiliary vector, falling back to 100 (the value on every
+// mainstream architecture) if that is unavailable.
+var ClockTicks = sync.OnceValue(func() float64 {
+	auxv, err := unix.Auxv()
+	if err != nil {
+		return 100
+	}
+	for _, kv := range auxv {
+		if kv[0] == atClkTck && kv[1] > 0 {
+			return float64(kv[1])
+		}
+	}
+	return 100
+})
@@ -0,0 +1,50 @@
+package use
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCommandRetries is how many times CommandOutput retries a failing
+// command unless SetCommandRetries changes it.
+const DefaultCommandRetries = 2
+
+// commandBackoff is the wait before the first retry; it doubles after each.
+const commandBackoff = 100 * time.Millisecond
+
+var commandRetries atomic.Int32
+
+func init() {
+	commandRetries.Store(DefaultCommandRetries)
+}
+
+// SetCommandRetries sets how many times CommandOutput retries a failing
+// command. Zero or a negative value disables retries.
+func SetCommandRetries(n int) {
+	commandRetries.Store(int32(max(n, 0)))
+}
+
+// CommandOutput runs a command and returns its stdout. macOS tools such as
+// iostat, vm_stat and `log show` occasionally fail transiently (resource
+// busy), so a failing run is retried with a short doubling backoff (100ms,
+// 200ms, ...) before its error is returned. A missing binary or a done ctx
+// is not retried.
+func CommandOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	retries := int(commandRetries.Load())
+	backoff := commandBackoff
+	for attempt := 0; ; attempt++ {
+		out, err := exec.CommandContext(ctx, name, args...).Output()
+		if err == nil || attempt >= retries || errors.Is(err, exec.ErrNotFound) || ctx.Err() != nil {
+			return out, err
+		}
+		select {
+		case <-ctx.Done():
+			return out, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
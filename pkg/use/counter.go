@@ -0,0 +1,58 @@
+package use
+
+import (
+	"fmt"
+	"time"
+)
+
+// CounterMode selects how monotonically increasing kernel counters are
+// reported. Both modes sample twice, so status is always judged on the rate;
+// only the reported value differs.
+type CounterMode string
+
+const (
+	CounterRate       CounterMode = "rate"       // per-second rate over the sample window (default)
+	CounterCumulative CounterMode = "cumulative" // raw total since boot
+)
+
+// CounterModal is implemented by collectors that report counter metrics.
+type CounterModal interface {
+	SetCounterMode(m CounterMode)
+}
+
+// CounterHook is embedded in collectors to provide a configurable counter mode.
+type CounterHook struct {
+	mode CounterMode
+}
+
+// SetCounterMode selects rate or cumulative reporting.
+func (h *CounterHook) SetCounterMode(m CounterMode) {
+	h.mode = m
+}
+
+// Cumulative reports whether counters should be reported as raw totals.
+func (h *CounterHook) Cumulative() bool {
+	return h.mode == CounterCumulative
+}
+
+// SinceBoot returns context for cumulative values, e.g. "since boot (up 3d4h)".
+func SinceBoot() string {
+	up, err := Uptime()
+	if err != nil {
+		return "since boot"
+	}
+	days := int(up.Hours()) / 24
+	hours := int(up.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("since boot (up %dd%dh)", days, hours)
+	}
+	return fmt.Sprintf("since boot (up %s)", up.Truncate(time.Minute))
+}
+
+// PerSecond converts a counter delta over an elapsed window to a rate.
+func PerSecond(delta uint64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(delta) / elapsed.Seconds()
+}
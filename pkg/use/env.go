@@ -0,0 +1,78 @@
+package use
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ThresholdsFromEnv layers threshold overrides from environment variables
+// over base. Recognized variables:
+//
+//	UMD_WARN_UTIL, UMD_CRIT_UTIL                 global utilization thresholds
+//	UMD_THRESHOLD_<RESOURCE>_WARN, _CRIT         per-resource, e.g. UMD_THRESHOLD_DISK_WARN=85
+//
+// Unparseable values are ignored.
+func ThresholdsFromEnv(base Thresholds) Thresholds {
+	t := base
+	if v, ok := envFloat("UMD_WARN_UTIL"); ok {
+		t.WarnUtil = v
+	}
+	if v, ok := envFloat("UMD_CRIT_UTIL"); ok {
+		t.CritUtil = v
+	}
+
+	// Copy overrides so the caller's map is not mutated
+	overrides := make(map[string]ResourceThresholds, len(base.Overrides))
+	for k, v := range base.Overrides {
+		overrides[k] = v
+	}
+
+	const prefix = "UMD_THRESHOLD_"
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, prefix)
+		idx := strings.LastIndex(rest, "_")
+		if idx <= 0 {
+			continue
+		}
+		resource := strings.ToLower(rest[:idx])
+		level := rest[idx+1:]
+
+		v, ok := envFloat(key)
+		if !ok {
+			continue
+		}
+		o := overrides[resource]
+		switch level {
+		case "WARN":
+			o.WarnUtil = v
+		case "CRIT":
+			o.CritUtil = v
+		default:
+			continue
+		}
+		overrides[resource] = o
+	}
+
+	if len(overrides) > 0 {
+		t.Overrides = overrides
+	}
+	return t
+}
+
+// envFloat parses a float from the named environment variable.
+func envFloat(key string) (float64, bool) {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
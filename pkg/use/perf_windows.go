@@ -0,0 +1,76 @@
+//go:build windows
+
+package use
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modpdh                          = windows.NewLazySystemDLL("pdh.dll")
+	procPdhOpenQueryW               = modpdh.NewProc("PdhOpenQueryW")
+	procPdhAddEnglishCounterW       = modpdh.NewProc("PdhAddEnglishCounterW")
+	procPdhCollectQueryData         = modpdh.NewProc("PdhCollectQueryData")
+	procPdhGetFormattedCounterValue = modpdh.NewProc("PdhGetFormattedCounterValue")
+	procPdhCloseQuery               = modpdh.NewProc("PdhCloseQuery")
+)
+
+// pdhFmtDouble asks PdhGetFormattedCounterValue for a float64.
+const pdhFmtDouble = 0x00000200
+
+// pdhCounterValue mirrors PDH_FMT_COUNTERVALUE for PDH_FMT_DOUBLE.
+type pdhCounterValue struct {
+	CStatus     uint32
+	_           uint32 // union alignment
+	DoubleValue float64
+}
+
+// PerfCounters reads Windows performance counters by their English paths,
+// which work on any display language, e.g. `\System\Processor Queue Length`.
+// Rate and percentage counters such as `\PhysicalDisk(_Total)\% Idle Time`
+// are computed between two samples, so a positive wait collects twice, wait
+// apart; instantaneous counters can use zero.
+func PerfCounters(wait time.Duration, paths ...string) (map[string]float64, error) {
+	var query windows.Handle
+	if r, _, _ := procPdhOpenQueryW.Call(0, 0, uintptr(unsafe.Pointer(&query))); r != 0 {
+		return nil, fmt.Errorf("PdhOpenQuery failed: 0x%x", r)
+	}
+	defer procPdhCloseQuery.Call(uintptr(query))
+
+	counters := make(map[string]windows.Handle, len(paths))
+	for _, path := range paths {
+		p, err := windows.UTF16PtrFromString(path)
+		if err != nil {
+			return nil, err
+		}
+		var counter windows.Handle
+		if r, _, _ := procPdhAddEnglishCounterW.Call(uintptr(query), uintptr(unsafe.Pointer(p)), 0, uintptr(unsafe.Pointer(&counter))); r != 0 {
+			return nil, fmt.Errorf("PdhAddEnglishCounter %s failed: 0x%x", path, r)
+		}
+		counters[path] = counter
+	}
+
+	if r, _, _ := procPdhCollectQueryData.Call(uintptr(query)); r != 0 {
+		return nil, fmt.Errorf("PdhCollectQueryData failed: 0x%x", r)
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+		if r, _, _ := procPdhCollectQueryData.Call(uintptr(query)); r != 0 {
+			return nil, fmt.Errorf("PdhCollectQueryData failed: 0x%x", r)
+		}
+	}
+
+	values := make(map[string]float64, len(counters))
+	for path, counter := range counters {
+		var value pdhCounterValue
+		if r, _, _ := procPdhGetFormattedCounterValue.Call(uintptr(counter), pdhFmtDouble, 0, uintptr(unsafe.Pointer(&value))); r != 0 {
+			return nil, fmt.Errorf("PdhGetFormattedCounterValue %s failed: 0x%x", path, r)
+		}
+		values[path] = value.DoubleValue
+	}
+	return values, nil
+}
@@ -0,0 +1,74 @@
+package use
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Access describes whether a collector can reach one of its data sources.
+type Access struct {
+	Collector  string `json:"collector"`
+	Source     string `json:"source"`
+	Accessible bool   `json:"accessible"`
+	Reason     string `json:"reason,omitempty"` // why it is inaccessible
+	Impact     string `json:"impact"`           // what degrades when the source is inaccessible
+}
+
+// Reasons a data source is inaccessible.
+const (
+	ReasonPermission = "permission denied"
+	ReasonMissing    = "not available on this host"
+)
+
+// Preflighter is implemented by collectors whose data sources may need
+// elevated privileges. Preflight must not perform a full collection.
+type Preflighter interface {
+	Preflight() []Access
+}
+
+// Preflight reports data-source accessibility for every collector that
+// supports it, without collecting.
+func Preflight(collectors []Collector) []Access {
+	var result []Access
+	for _, col := range collectors {
+		if p, ok := col.(Preflighter); ok {
+			result = append(result, p.Preflight()...)
+		}
+	}
+	return result
+}
+
+// ReadAccess reports whether the current user can open path for reading,
+// and if not, why.
+func ReadAccess(path string) (bool, string) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return false, ReasonPermission
+		}
+		return false, ReasonMissing
+	}
+	f.Close()
+	return true, ""
+}
+
+// RunAccess reports whether a command exists and exits successfully within a
+// short timeout, and if not, why. Callers should pass cheap arguments.
+func RunAccess(name string, args ...string) (bool, string) {
+	if _, err := exec.LookPath(name); err != nil {
+		return false, ReasonMissing
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := exec.CommandContext(ctx, name, args...).Run(); err != nil {
+		return false, ReasonPermission
+	}
+	return true, ""
+}
+
+// IsRoot reports whether umd is running with root privileges.
+func IsRoot() bool {
+	return os.Geteuid() == 0
+}
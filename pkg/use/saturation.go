@@ -0,0 +1,69 @@
+package use
+
+import "strings"
+
+// SaturationLimits are the warning and critical cutoffs for a metric that
+// isn't a utilization percentage (load ratio, queue depth, rates, counts).
+// Values above Warn are warnings; values above Crit, if set, are errors.
+type SaturationLimits struct {
+	Warn float64 `json:"warn"`
+	Crit float64 `json:"crit,omitempty"`
+}
+
+// defaultSaturation holds the built-in limits, keyed by SaturationKey.
+// Platform-specific limits live in platformSaturation.
+var defaultSaturation = map[string]SaturationLimits{
+	"cpu|saturation":              {Warn: 1.0},            // load average per CPU
+	"scheduler|utilization":       {Warn: 2, Crit: 4},     // run queue per CPU
+	"scheduler|saturation":        {Warn: 100000},         // context switches/s
+	"tcp|utilization":             {Warn: 1.0, Crit: 5.0}, // retransmit %
+	"tcp|saturation":              {Warn: 0},              // listen overflows
+	"tcp|errors":                  {Warn: 1000},           // TIME_WAIT sockets
+	"filesystem (fds)|saturation": {Warn: 70, Crit: 90},   // FD table %
+	"filesystem (fds)|errors":     {Warn: 100},            // FD growth/min (leak)
+}
+
+// SaturationKey returns the lookup key for a resource and metric type, e.g.
+// ("Disk (sda)", Saturation) -> "disk (sda)|saturation".
+func SaturationKey(resource string, mtype MetricType) string {
+	return strings.ToLower(resource) + "|" + string(mtype)
+}
+
+// SaturationLimitsFor returns the limits for a resource and metric type: an
+// override for the exact resource, then its base name, then the built-in
+// default. ok is false if no limit is defined.
+func (t Thresholds) SaturationLimitsFor(resource string, mtype MetricType) (SaturationLimits, bool) {
+	base, _, _ := strings.Cut(resource, " (")
+	keys := []string{SaturationKey(resource, mtype), SaturationKey(base, mtype)}
+	for _, k := range keys {
+		if l, ok := t.Saturation[k]; ok {
+			return l, true
+		}
+	}
+	for _, k := range keys {
+		if l, ok := platformSaturation[k]; ok {
+			return l, true
+		}
+		if l, ok := defaultSaturation[k]; ok {
+			return l, true
+		}
+	}
+	return SaturationLimits{}, false
+}
+
+// EvaluateSaturationFor returns the status of a saturation-style value using
+// the configured or built-in limits for the resource and metric type. Values
+// with no defined limit are OK.
+func (t Thresholds) EvaluateSaturationFor(resource string, mtype MetricType, value float64) Status {
+	l, ok := t.SaturationLimitsFor(resource, mtype)
+	if !ok {
+		return StatusOK
+	}
+	if l.Crit > 0 && value > l.Crit {
+		return StatusError
+	}
+	if value > l.Warn {
+		return StatusWarning
+	}
+	return StatusOK
+}
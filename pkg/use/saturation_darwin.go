@@ -0,0 +1,10 @@
+//go:build darwin
+
+package use
+
+// platformSaturation holds built-in limits whose metric differs by platform.
+var platformSaturation = map[string]SaturationLimits{
+	"disk|saturation":  {Warn: 1000},         // transfers per second (iostat)
+	"gpu|saturation":   {Warn: 70, Crit: 90}, // GPU memory % of unified memory
+	"membw|saturation": {Warn: 70, Crit: 90}, // memory bandwidth % of chip peak
+}
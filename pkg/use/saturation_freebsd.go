@@ -0,0 +1,6 @@
+//go:build freebsd
+
+package use
+
+// platformSaturation holds built-in limits whose metric differs by platform.
+var platformSaturation = map[string]SaturationLimits{}
@@ -0,0 +1,19 @@
+//go:build linux
+
+package use
+
+// platformSaturation holds built-in limits whose metric differs by platform.
+var platformSaturation = map[string]SaturationLimits{
+	"disk|saturation":              {Warn: 1.0},           // average queue size (/proc/diskstats)
+	"vmem|utilization":             {Warn: 10, Crit: 100}, // major faults/s
+	"vmem|errors":                  {Warn: 10, Crit: 30},  // dirty pages % of MemTotal
+	"vmem (writeback)|saturation":  {Warn: 1000},          // net pages dirtied/s
+	"vmem (thp)|saturation":        {Warn: 0},             // compaction stalls/s
+	"vmem (khugepaged)|saturation": {Warn: 10},            // khugepaged CPU %
+	"psi|saturation":               {Warn: 5, Crit: 20},   // some avg10 stall %
+	"numa|saturation":              {Warn: 5, Crit: 20},   // numa_miss % of allocations
+	"cgroup (cpu)|saturation":      {Warn: 5, Crit: 25},   // periods throttled by cpu.max
+	"irq|saturation":               {Warn: 200000},        // interrupts/s, all CPUs
+	"audit|saturation":             {Warn: 50, Crit: 90},  // backlog % of backlog_limit
+	"cpu (isolated)|errors":        {Warn: 10, Crit: 10},  // busiest isolated CPU %
+}
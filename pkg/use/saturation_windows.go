@@ -0,0 +1,10 @@
+//go:build windows
+
+package use
+
+// platformSaturation holds built-in limits whose metric differs by platform.
+var platformSaturation = map[string]SaturationLimits{
+	"cpu|saturation":    {Warn: 2},            // processor queue length per CPU
+	"memory|saturation": {Warn: 80, Crit: 95}, // commit charge % of commit limit
+	"disk|saturation":   {Warn: 2},            // outstanding I/Os, all disks
+}
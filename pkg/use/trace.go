@@ -0,0 +1,29 @@
+package use
+
+// Tracer receives the raw source data collectors parse, for debugging values
+// that look wrong. debug.TraceLogger implements it.
+type Tracer interface {
+	LogValue(collector, source, rawStr string, parsed float64)
+}
+
+// Traceable is implemented by collectors that can report raw source data.
+type Traceable interface {
+	SetTracer(t Tracer)
+}
+
+// TraceHook is embedded in collectors to provide optional tracing.
+type TraceHook struct {
+	tracer Tracer
+}
+
+// SetTracer enables raw source tracing. A nil tracer disables it.
+func (h *TraceHook) SetTracer(t Tracer) {
+	h.tracer = t
+}
+
+// Trace logs a raw source reading and its parsed value if tracing is enabled.
+func (h *TraceHook) Trace(collector, source, raw string, parsed float64) {
+	if h.tracer != nil {
+		h.tracer.LogValue(collector, source, raw, parsed)
+	}
+}
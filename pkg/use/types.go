@@ -1,6 +1,8 @@
 // Package use provides types and utilities for the USE Method system analysis.
 package use
 
+import "strings"
+
 // MetricType represents the type of USE metric being measured.
 type MetricType string
 
@@ -20,21 +22,83 @@ const (
 	StatusUnknown Status = "unknown"
 )
 
+// Unit identifies the canonical base unit of a check's RawValue.
+type Unit string
+
+const (
+	UnitPercent        Unit = "percent"
+	UnitBytes          Unit = "bytes"
+	UnitBytesPerSecond Unit = "bytes/s"
+	UnitCount          Unit = "count"
+	UnitPerSecond      Unit = "1/s"
+	UnitPerMinute      Unit = "1/min"
+	UnitRatio          Unit = "ratio"
+	UnitCelsius        Unit = "celsius"
+)
+
 // Check represents a single USE method check result.
 type Check struct {
 	Resource    string     `json:"resource"`
 	Type        MetricType `json:"type"`
 	Value       string     `json:"value"`
 	RawValue    float64    `json:"raw_value"`
+	Unit        Unit       `json:"unit,omitempty"`
 	Status      Status     `json:"status"`
 	Description string     `json:"description"`
 	Command     string     `json:"command"`
+
+	// Kind tells apart checks that share a resource and type, such as
+	// "inodes" for inode usage next to disk capacity on "Filesystem (/)",
+	// or "inflight" for in-flight I/Os next to queue size on "Disk (sda)".
+	// Empty for most checks.
+	Kind string `json:"kind,omitempty"`
+
+	// ShowsRaw marks Value as RawValue rendered in Unit, followed by Label
+	// when set, so output formats may render it afresh from RawValue
+	// (binary bytes, a fixed precision). Checks whose Value shows another
+	// figure, or several ("swap: 0/s, scan: 0/s"), leave it unset and are
+	// shown as collected.
+	ShowsRaw bool `json:"-"`
+
+	// Label is the text after the number in a ShowsRaw Value, such as
+	// "inodes" in "3.0% inodes" or "csw/s" in "120 csw/s".
+	Label string `json:"-"`
+
+	// Collector names the collector that produced the check, as set by
+	// Checker. Its per-resource thresholds apply to the check even when the
+	// resource is named differently (the disk collector's "Filesystem (/)").
+	Collector string `json:"-"`
+}
+
+// Key identifies a check across samples: "Resource|Type", with "|Kind"
+// appended when Kind is set. Code that tracks checks over time (alert
+// state, baselines, fill projection, metric series) should key on it.
+func (c Check) Key() string {
+	key := c.Resource + "|" + string(c.Type)
+	if c.Kind != "" {
+		key += "|" + c.Kind
+	}
+	return key
 }
 
 // Thresholds defines warning and critical thresholds for utilization metrics.
 type Thresholds struct {
 	WarnUtil float64
 	CritUtil float64
+
+	// Overrides holds per-resource thresholds keyed by lower-case collector
+	// name (e.g. "disk"). Zero fields fall back to the global values.
+	Overrides map[string]ResourceThresholds
+
+	// Saturation overrides the built-in limits for non-percentage metrics,
+	// keyed by SaturationKey (e.g. "scheduler|saturation").
+	Saturation map[string]SaturationLimits
+}
+
+// ResourceThresholds overrides utilization thresholds for a single resource.
+type ResourceThresholds struct {
+	WarnUtil float64
+	CritUtil float64
 }
 
 // DefaultThresholds returns the default threshold values.
@@ -45,6 +109,22 @@ func DefaultThresholds() Thresholds {
 	}
 }
 
+// ForResource returns the thresholds to apply to the named collector, with any
+// per-resource override layered over the global values.
+func (t Thresholds) ForResource(name string) Thresholds {
+	o, ok := t.Overrides[strings.ToLower(name)]
+	if !ok {
+		return t
+	}
+	if o.WarnUtil != 0 {
+		t.WarnUtil = o.WarnUtil
+	}
+	if o.CritUtil != 0 {
+		t.CritUtil = o.CritUtil
+	}
+	return t
+}
+
 // EvaluateUtilization returns the appropriate status based on utilization percentage.
 func (t Thresholds) EvaluateUtilization(percent float64) Status {
 	if percent >= t.CritUtil {
@@ -56,6 +136,32 @@ func (t Thresholds) EvaluateUtilization(percent float64) Status {
 	return StatusOK
 }
 
+// EvaluateUtilizationFor evaluates utilization for a specific check resource,
+// applying an override for the exact resource (e.g. "filesystem (/)") or its
+// base name (e.g. "filesystem") before falling back to t. This lets checks
+// from one collector, such as disk busy % and filesystem capacity, use
+// different thresholds.
+func (t Thresholds) EvaluateUtilizationFor(resource string, percent float64) Status {
+	return t.UtilizationFor(resource).EvaluateUtilization(percent)
+}
+
+// UtilizationFor returns t with overrides for the resource's base name and
+// then the exact resource applied, as used by EvaluateUtilizationFor.
+func (t Thresholds) UtilizationFor(resource string) Thresholds {
+	base, _, _ := strings.Cut(resource, " (")
+	return t.ForResource(base).ForResource(resource)
+}
+
+// ForCheck returns the utilization thresholds c was evaluated with: the
+// override for its collector, as Checker applies it, then those for its
+// resource, as EvaluateUtilizationFor applies them.
+func (t Thresholds) ForCheck(c Check) Thresholds {
+	if c.Collector != "" {
+		t = t.ForResource(c.Collector)
+	}
+	return t.UtilizationFor(c.Resource)
+}
+
 // EvaluateErrors returns status based on error count.
 func EvaluateErrors(count int64) Status {
 	if count > 0 {
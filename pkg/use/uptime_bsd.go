@@ -0,0 +1,18 @@
+//go:build darwin || freebsd
+
+package use
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Uptime returns the time since boot.
+func Uptime() (time.Duration, error) {
+	tv, err := unix.SysctlTimeval("kern.boottime")
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(time.Unix(tv.Unix())), nil
+}
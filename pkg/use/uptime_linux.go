@@ -0,0 +1,18 @@
+//go:build linux
+
+package use
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Uptime returns the time since boot.
+func Uptime() (time.Duration, error) {
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err != nil {
+		return 0, err
+	}
+	return time.Duration(info.Uptime) * time.Second, nil
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package use
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// Uptime returns the time since boot.
+func Uptime() (time.Duration, error) {
+	return windows.DurationSinceBoot(), nil
+}
@@ -0,0 +1,67 @@
+// Package watch runs collectors on an interval and streams each sample.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
+)
+
+// Run collects from all collectors every interval, passing each sample to
+// emit, until ctx is cancelled. The first sample is taken immediately. A
+// collector that fails or panics yields an unknown check for that tick and
+// is retried on the next.
+func Run(ctx context.Context, collectors []use.Collector, thresholds use.Thresholds, interval time.Duration, emit func([]use.Check)) {
+	RunWith(ctx, use.NewChecker(thresholds, nil), collectors, interval, emit)
+}
+
+// RunWith is like Run but uses a configured checker, e.g. one with a tracer
+// or counter mode set.
+func RunWith(ctx context.Context, checker *use.Checker, collectors []use.Collector, interval time.Duration, emit func([]use.Check)) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		checks := checker.RunAllContext(ctx, collectors)
+		if ctx.Err() != nil {
+			// Cancelled mid-run; don't emit a sample of abandoned collectors
+			return
+		}
+		emit(checks)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample is one NDJSON line: a timestamped set of checks and their summary.
+type Sample struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Checks    []use.Check `json:"checks"`
+	Summary   use.Summary `json:"summary"`
+}
+
+// NDJSON returns an emit function that writes each sample as one JSON object
+// per line, the format log shippers expect.
+func NDJSON(w io.Writer) func([]use.Check) {
+	enc := json.NewEncoder(w)
+	return func(checks []use.Check) {
+		enc.Encode(Sample{
+			Timestamp: time.Now().UTC(),
+			Checks:    checks,
+			Summary:   use.Summarize(checks),
+		})
+	}
+}
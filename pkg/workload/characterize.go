@@ -4,6 +4,8 @@ package workload
 import (
 	"fmt"
 	"io"
+	"os/user"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +19,21 @@ type ProcessInfo struct {
 	MemPct  float64 `json:"mem_pct"`
 	Command string  `json:"command"`
 	State   string  `json:"state"`
+
+	// ReadBps and WriteBps are storage I/O rates in bytes/s, set only for
+	// TopIOProcesses.
+	ReadBps  float64 `json:"read_bytes_per_sec,omitempty"`
+	WriteBps float64 `json:"write_bytes_per_sec,omitempty"`
+}
+
+// RedactedCommand returns the command with arguments masked, keeping only the
+// executable, so output can be shared without leaking secrets passed as args.
+func (p ProcessInfo) RedactedCommand() string {
+	fields := strings.Fields(p.Command)
+	if len(fields) <= 1 {
+		return p.Command
+	}
+	return fields[0] + " ***"
 }
 
 // Report holds the complete workload characterization.
@@ -38,6 +55,66 @@ var (
 	wlOK     = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
 )
 
+// Redact masks process arguments in all process lists.
+func (r *Report) Redact() {
+	for _, procs := range [][]ProcessInfo{r.TopCPUProcesses, r.TopMemProcesses, r.TopIOProcesses} {
+		for i := range procs {
+			procs[i].Command = procs[i].RedactedCommand()
+		}
+	}
+}
+
+// FilterByUser restricts the process lists to processes owned by user, given
+// as a name or numeric UID. Linux reports owners by UID and macOS by name, so
+// both forms are resolved.
+func (r *Report) FilterByUser(name string) {
+	owners := map[string]bool{name: true}
+	if u, err := user.Lookup(name); err == nil {
+		owners[u.Uid] = true
+		owners[u.Username] = true
+	} else if u, err := user.LookupId(name); err == nil {
+		owners[u.Username] = true
+	}
+	r.filter(func(p ProcessInfo) bool { return owners[p.User] })
+}
+
+// FilterByCommand restricts the process lists to processes whose command
+// matches the regular expression pattern.
+func (r *Report) FilterByCommand(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid command pattern: %w", err)
+	}
+	r.filter(func(p ProcessInfo) bool { return re.MatchString(p.Command) })
+	return nil
+}
+
+// filter keeps the processes matching keep in every list, preserving their
+// ranking, and recounts process states over the processes that remain.
+func (r *Report) filter(keep func(ProcessInfo) bool) {
+	r.TopCPUProcesses = filterProcesses(r.TopCPUProcesses, keep)
+	r.TopMemProcesses = filterProcesses(r.TopMemProcesses, keep)
+	r.TopIOProcesses = filterProcesses(r.TopIOProcesses, keep)
+
+	r.ProcessStateCounts = make(map[string]int)
+	for _, p := range r.TopCPUProcesses {
+		if p.State != "" {
+			r.ProcessStateCounts[p.State[:1]]++
+		}
+	}
+	r.Summary = fmt.Sprintf("Load trend: %s. %d matching processes.", r.LoadTrend, len(r.TopCPUProcesses))
+}
+
+func filterProcesses(procs []ProcessInfo, keep func(ProcessInfo) bool) []ProcessInfo {
+	var kept []ProcessInfo
+	for _, p := range procs {
+		if keep(p) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
 // Render outputs the workload report with lipgloss styling.
 func (r *Report) Render(w io.Writer, topN int) {
 	fmt.Fprintln(w, wlTitle.Render("Workload Characterization Report"))
@@ -104,12 +181,46 @@ func (r *Report) Render(w io.Writer, topN int) {
 		fmt.Fprintln(w)
 	}
 
+	// Top I/O
+	if len(r.TopIOProcesses) > 0 {
+		fmt.Fprintln(w, wlTitle.Render("Top I/O Consumers"))
+		fmt.Fprintf(w, "  %s %s %s %s %s\n",
+			wlHeader.Render("PID     "),
+			wlHeader.Render("USER       "),
+			wlHeader.Render("READ/s    "),
+			wlHeader.Render("WRITE/s   "),
+			wlHeader.Render("COMMAND"))
+		fmt.Fprintln(w, "  "+wlDim.Render(strings.Repeat("─", 60)))
+		limit := topN
+		if limit > len(r.TopIOProcesses) {
+			limit = len(r.TopIOProcesses)
+		}
+		for _, p := range r.TopIOProcesses[:limit] {
+			fmt.Fprintf(w, "  %-8d %-12s %-11s %-11s %s\n", p.PID, p.User, formatRate(p.ReadBps), formatRate(p.WriteBps), p.Command)
+		}
+		fmt.Fprintln(w)
+	}
+
 	// Summary
 	if r.Summary != "" {
 		fmt.Fprintf(w, "%s %s\n", wlTitle.Render("Summary:"), r.Summary)
 	}
 }
 
+// formatRate formats a byte rate, e.g. "12.3 MB/s".
+func formatRate(bps float64) string {
+	const unit = 1024
+	if bps < unit {
+		return fmt.Sprintf("%.0f B/s", bps)
+	}
+	div, exp := float64(unit), 0
+	for n := bps / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB/s", bps/div, "KMGTPE"[exp])
+}
+
 // characterizeLoadTrend determines if load is increasing, decreasing, or stable.
 func characterizeLoadTrend(load1, load5, load15 float64) string {
 	if load1 > load5*1.2 && load5 > load15*1.2 {
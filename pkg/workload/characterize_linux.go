@@ -7,11 +7,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/danpilch/umd/pkg/use"
 )
 
+// sampleWindow is the interval between the two /proc/[pid] reads used to
+// compute per-process CPU and I/O rates.
+const sampleWindow = 500 * time.Millisecond
+
+// procSample is one process's cumulative counters at a point in time.
+type procSample struct {
+	info     ProcessInfo
+	cpuTicks uint64 // utime + stime
+	ioRead   uint64 // read_bytes, if /proc/[pid]/io was readable
+	ioWrite  uint64
+	hasIO    bool
+}
+
 // Characterize gathers workload information on Linux.
 func Characterize() (*Report, error) {
 	report := &Report{
@@ -30,8 +47,9 @@ func Characterize() (*Report, error) {
 	report.LoadTrend = characterizeLoadTrend(
 		report.LoadAverages[0], report.LoadAverages[1], report.LoadAverages[2])
 
-	// Read all processes from /proc/[pid]/stat
-	procs, err := readAllProcesses()
+	// Sample /proc/[pid] twice so CPU% and I/O reflect current activity
+	// rather than lifetime totals
+	procs, err := sampleProcesses(sampleWindow)
 	if err == nil {
 		// Count process states
 		for _, p := range procs {
@@ -51,6 +69,17 @@ func Characterize() (*Report, error) {
 			return memProcs[i].MemPct > memProcs[j].MemPct
 		})
 		report.TopMemProcesses = memProcs
+
+		// Processes doing storage I/O, busiest first
+		for _, p := range procs {
+			if p.ReadBps > 0 || p.WriteBps > 0 {
+				report.TopIOProcesses = append(report.TopIOProcesses, p)
+			}
+		}
+		sort.Slice(report.TopIOProcesses, func(i, j int) bool {
+			a, b := report.TopIOProcesses[i], report.TopIOProcesses[j]
+			return a.ReadBps+a.WriteBps > b.ReadBps+b.WriteBps
+		})
 	}
 
 	// Summary
@@ -60,7 +89,53 @@ func Characterize() (*Report, error) {
 	return report, nil
 }
 
-func readAllProcesses() ([]ProcessInfo, error) {
+// sampleProcesses reads every process twice, window apart, and returns the
+// processes alive at the second read with CPU% and I/O rates over the
+// window. CPU% is top-style: 100 is one full core, capped at all cores.
+// /proc/[pid]/io is only readable for the caller's own processes unless
+// running as root; I/O rates are left zero for the rest.
+func sampleProcesses(window time.Duration) ([]ProcessInfo, error) {
+	first, err := readAllProcesses()
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[int]procSample, len(first))
+	for _, s := range first {
+		before[s.info.PID] = s
+	}
+	start := time.Now()
+
+	time.Sleep(window)
+
+	second, err := readAllProcesses()
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start).Seconds()
+	maxPct := float64(runtime.NumCPU()) * 100
+
+	procs := make([]ProcessInfo, 0, len(second))
+	for _, s := range second {
+		p := s.info
+		// A PID missing from the first read is a new process; its whole
+		// lifetime falls inside the window
+		b, ok := before[p.PID]
+		if !ok {
+			b = procSample{hasIO: s.hasIO}
+		}
+		if s.cpuTicks >= b.cpuTicks {
+			p.CPUPct = min(float64(s.cpuTicks-b.cpuTicks)/use.ClockTicks()/elapsed*100, maxPct)
+		}
+		if s.hasIO && b.hasIO && s.ioRead >= b.ioRead && s.ioWrite >= b.ioWrite {
+			p.ReadBps = float64(s.ioRead-b.ioRead) / elapsed
+			p.WriteBps = float64(s.ioWrite-b.ioWrite) / elapsed
+		}
+		procs = append(procs, p)
+	}
+	return procs, nil
+}
+
+func readAllProcesses() ([]procSample, error) {
 	dirs, err := filepath.Glob("/proc/[0-9]*/stat")
 	if err != nil {
 		return nil, err
@@ -69,21 +144,23 @@ func readAllProcesses() ([]ProcessInfo, error) {
 	// Get total memory for percentage calculation
 	totalMem := getTotalMemory()
 
-	var procs []ProcessInfo
+	var procs []procSample
 	for _, statPath := range dirs {
-		p, err := readProcessStat(statPath, totalMem)
+		s, err := readProcessStat(statPath, totalMem)
 		if err != nil {
 			continue
 		}
-		procs = append(procs, p)
+		s.ioRead, s.ioWrite, err = readProcessIO(s.info.PID)
+		s.hasIO = err == nil
+		procs = append(procs, s)
 	}
 	return procs, nil
 }
 
-func readProcessStat(path string, totalMem uint64) (ProcessInfo, error) {
+func readProcessStat(path string, totalMem uint64) (procSample, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return ProcessInfo{}, err
+		return procSample{}, err
 	}
 
 	content := string(data)
@@ -91,7 +168,7 @@ func readProcessStat(path string, totalMem uint64) (ProcessInfo, error) {
 	start := strings.Index(content, "(")
 	end := strings.LastIndex(content, ")")
 	if start < 0 || end < 0 {
-		return ProcessInfo{}, fmt.Errorf("invalid stat format")
+		return procSample{}, fmt.Errorf("invalid stat format")
 	}
 
 	comm := content[start+1 : end]
@@ -100,7 +177,7 @@ func readProcessStat(path string, totalMem uint64) (ProcessInfo, error) {
 	pid, _ := strconv.Atoi(pidStr)
 
 	if len(rest) < 22 {
-		return ProcessInfo{}, fmt.Errorf("insufficient fields")
+		return procSample{}, fmt.Errorf("insufficient fields")
 	}
 
 	state := rest[0]
@@ -109,15 +186,7 @@ func readProcessStat(path string, totalMem uint64) (ProcessInfo, error) {
 	stime, _ := strconv.ParseUint(rest[12], 10, 64)
 	// vsize is field 22, rss is field 23
 	rss, _ := strconv.ParseUint(rest[21], 10, 64)
-	rssBytes := rss * 4096 // pages to bytes
-
-	// CPU% is approximate - based on total time
-	cpuTicks := float64(utime + stime)
-	// Normalize to approximate percentage (rough)
-	cpuPct := cpuTicks / 100.0
-	if cpuPct > 100 {
-		cpuPct = 100
-	}
+	rssBytes := rss * uint64(os.Getpagesize())
 
 	var memPct float64
 	if totalMem > 0 {
@@ -127,16 +196,41 @@ func readProcessStat(path string, totalMem uint64) (ProcessInfo, error) {
 	// Get user from /proc/[pid]/status
 	user := getProcessUser(pid)
 
-	return ProcessInfo{
-		PID:     pid,
-		User:    user,
-		CPUPct:  cpuPct,
-		MemPct:  memPct,
-		Command: comm,
-		State:   state,
+	return procSample{
+		info: ProcessInfo{
+			PID:     pid,
+			User:    user,
+			MemPct:  memPct,
+			Command: comm,
+			State:   state,
+		},
+		cpuTicks: utime + stime,
 	}, nil
 }
 
+// readProcessIO returns read_bytes and write_bytes (bytes fetched from or
+// sent to storage) from /proc/[pid]/io.
+func readProcessIO(pid int) (uint64, uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/io", pid))
+	if err != nil {
+		return 0, 0, err
+	}
+	var read, write uint64
+	for _, line := range strings.Split(string(data), "\n") {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "read_bytes":
+			read, _ = strconv.ParseUint(strings.TrimSpace(val), 10, 64)
+		case "write_bytes":
+			write, _ = strconv.ParseUint(strings.TrimSpace(val), 10, 64)
+		}
+	}
+	return read, write, nil
+}
+
 func getProcessUser(pid int) string {
 	path := fmt.Sprintf("/proc/%d/status", pid)
 	file, err := os.Open(path)